@@ -0,0 +1,464 @@
+// category.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         CATEGORY TREE
+// ══════════════════════════════════════════════════════════════════
+
+const categoryPrefsFile = "categories.json"
+
+// sidebarW is the width of the category tree sidebar.
+const sidebarW = 26
+
+// categoryPrefs is the persisted category ordering and tree state.
+type categoryPrefs struct {
+	Order     []string `json:"order,omitempty"`     // category IDs, first wins
+	Collapsed []string `json:"collapsed,omitempty"` // folded parents in the sidebar
+	Sidebar   bool     `json:"sidebar,omitempty"`   // tree sidebar instead of tabs
+}
+
+func loadCategoryPrefs() (categoryPrefs, error) {
+	var p categoryPrefs
+	err := loadJSON(statePath(categoryPrefsFile), &p)
+	return p, err
+}
+
+func (p categoryPrefs) save() error {
+	return saveJSON(statePath(categoryPrefsFile), p)
+}
+
+func (p categoryPrefs) isCollapsed(id string) bool {
+	for _, c := range p.Collapsed {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// orderCategories sorts cats by their position in order. Categories not
+// listed keep their relative place after the listed ones.
+func orderCategories(cats []Category, order []string) {
+	if len(order) == 0 {
+		return
+	}
+	rank := make(map[string]int, len(order))
+	for i, id := range order {
+		rank[id] = i
+	}
+	pos := func(c Category) int {
+		if r, ok := rank[c.ID]; ok {
+			return r
+		}
+		return len(order)
+	}
+	sort.SliceStable(cats, func(i, j int) bool {
+		return pos(cats[i]) < pos(cats[j])
+	})
+}
+
+// treeRow is one category in tree order.
+type treeRow struct {
+	Index int
+	Depth int
+}
+
+// categoryTree walks the categories depth-first in slice order. Children
+// of collapsed parents are skipped unless all is set.
+func (m *Model) categoryTree(all bool) []treeRow {
+	var rows []treeRow
+	var walk func(parent string, depth int)
+	walk = func(parent string, depth int) {
+		for i, c := range m.categories {
+			if c.Parent != parent || c.ID == parent {
+				continue
+			}
+			rows = append(rows, treeRow{Index: i, Depth: depth})
+			if all || !m.catPrefs.isCollapsed(c.ID) {
+				walk(c.ID, depth+1)
+			}
+		}
+	}
+	walk("", 0)
+
+	// Orphans whose parent is missing are shown at the top level
+	if all {
+		seen := make(map[int]bool, len(rows))
+		for _, r := range rows {
+			seen[r.Index] = true
+		}
+		for i := range m.categories {
+			if !seen[i] {
+				rows = append(rows, treeRow{Index: i})
+			}
+		}
+	}
+	return rows
+}
+
+// hasChildren reports whether category ci has subcategories.
+func (m *Model) hasChildren(ci int) bool {
+	id := m.categories[ci].ID
+	for _, c := range m.categories {
+		if c.Parent == id {
+			return true
+		}
+	}
+	return false
+}
+
+// rootOf returns the top-level ancestor of category ci.
+func (m *Model) rootOf(ci int) int {
+	for depth := 0; depth < len(m.categories); depth++ {
+		p := categoryIndex(m.categories, m.categories[ci].Parent)
+		if m.categories[ci].Parent == "" || p < 0 {
+			break
+		}
+		ci = p
+	}
+	return ci
+}
+
+// isDescendant reports whether ci is anc or nested below it.
+func (m *Model) isDescendant(ci, anc int) bool {
+	for depth := 0; depth <= len(m.categories); depth++ {
+		if ci == anc {
+			return true
+		}
+		p := m.categories[ci].Parent
+		if p == "" {
+			return false
+		}
+		if ci = categoryIndex(m.categories, p); ci < 0 {
+			return false
+		}
+	}
+	return false
+}
+
+// categoryPath is the display path of ci, e.g. "Git › Stash".
+func (m *Model) categoryPath(ci int) string {
+	parts := []string{m.categories[ci].Name}
+	for depth := 0; depth < len(m.categories); depth++ {
+		p := categoryIndex(m.categories, m.categories[ci].Parent)
+		if m.categories[ci].Parent == "" || p < 0 {
+			break
+		}
+		ci = p
+		parts = append([]string{m.categories[ci].Name}, parts...)
+	}
+	return strings.Join(parts, " › ")
+}
+
+// categoryCommands returns the commands of ci and all its subcategories.
+func (m *Model) categoryCommands(ci int) []Command {
+	if !m.hasChildren(ci) {
+		return m.categories[ci].Commands
+	}
+	var cmds []Command
+	for _, r := range m.categoryTree(true) {
+		if m.isDescendant(r.Index, ci) {
+			cmds = append(cmds, m.categories[r.Index].Commands...)
+		}
+	}
+	return cmds
+}
+
+// ownerOf returns the category holding cmd within the current category,
+// which may be one of its subcategories, or anywhere when filtering by tag.
+func (m *Model) ownerOf(cmd string) int {
+	for _, r := range m.categoryTree(true) {
+		if m.tagFilter.active() {
+			// Tag results span every category
+			if m.categories[r.Index].Virtual {
+				continue
+			}
+		} else if !m.isDescendant(r.Index, m.catIndex) {
+			continue
+		}
+		for _, c := range m.categories[r.Index].Commands {
+			if c.Cmd == cmd {
+				return r.Index
+			}
+		}
+	}
+	return m.catIndex
+}
+
+// tabEntries are the categories shown in the tab row: the top level, with
+// the subcategories of the active one expanded after it.
+func (m *Model) tabEntries() []treeRow {
+	root := m.rootOf(m.catIndex)
+	var rows []treeRow
+	for _, r := range m.categoryTree(true) {
+		if r.Depth == 0 || m.rootOf(r.Index) == root {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// rootNumber returns the 1-based digit key of a top-level category, or 0.
+func (m *Model) rootNumber(ci int) int {
+	n := 0
+	for _, r := range m.categoryTree(true) {
+		if r.Depth == 0 {
+			n++
+			if r.Index == ci {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// selectCategory switches to category ci.
+func (m *Model) selectCategory(ci int) {
+	if ci < 0 || ci >= len(m.categories) {
+		return
+	}
+	m.catIndex = ci
+	m.tagFilter = tagFilter{}
+	m.resetSelection()
+	m.calculateLayout()
+}
+
+// stepCategory moves delta categories through the tree order.
+func (m *Model) stepCategory(delta int) {
+	rows := m.categoryTree(true)
+	if len(rows) == 0 {
+		return
+	}
+	pos := 0
+	for i, r := range rows {
+		if r.Index == m.catIndex {
+			pos = i
+		}
+	}
+	pos = (pos + delta + len(rows)) % len(rows)
+	m.selectCategory(rows[pos].Index)
+}
+
+// selectRoot switches to the n-th (0-based) top-level category.
+func (m *Model) selectRoot(n int) {
+	for _, r := range m.categoryTree(true) {
+		if r.Depth != 0 {
+			continue
+		}
+		if n == 0 {
+			m.selectCategory(r.Index)
+			return
+		}
+		n--
+	}
+}
+
+// findCategory resolves a typed name: exact ID or name first, then a prefix
+// of the name or path, then a substring.
+func (m *Model) findCategory(query string) int {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return -1
+	}
+	if ci := categoryIndex(m.categories, q); ci >= 0 {
+		return ci
+	}
+	rows := m.categoryTree(true)
+	match := func(ok func(name, path string) bool) int {
+		for _, r := range rows {
+			c := m.categories[r.Index]
+			name := strings.ToLower(c.Name)
+			path := strings.ToLower(strings.ReplaceAll(m.categoryPath(r.Index), " › ", "/"))
+			if ok(name, path) || ok(c.ID, path) {
+				return r.Index
+			}
+		}
+		return -1
+	}
+	if ci := match(func(name, path string) bool {
+		return strings.HasPrefix(name, q) || strings.HasPrefix(path, q)
+	}); ci >= 0 {
+		return ci
+	}
+	return match(func(name, path string) bool {
+		return strings.Contains(name, q) || strings.Contains(path, q)
+	})
+}
+
+// openJumpForm asks for a category name to jump to.
+func (m *Model) openJumpForm() {
+	m.form = newInputForm("Jump to Category", formJump,
+		newFormField("Category", "", "name, id or path like git/stash"),
+	)
+}
+
+func (m *Model) submitJumpForm() error {
+	ci := m.findCategory(m.form.value("Category"))
+	if ci < 0 {
+		return errors.New("no category matches")
+	}
+	m.selectCategory(ci)
+	return nil
+}
+
+// moveCategory swaps the current category with its previous (delta < 0)
+// or next sibling and persists the new order.
+func (m *Model) moveCategory(delta int) {
+	parent := m.categories[m.catIndex].Parent
+	var siblings []int
+	for _, r := range m.categoryTree(true) {
+		if m.categories[r.Index].Parent == parent {
+			siblings = append(siblings, r.Index)
+		}
+	}
+	pos := -1
+	for i, ci := range siblings {
+		if ci == m.catIndex {
+			pos = i
+		}
+	}
+	to := pos + delta
+	if pos < 0 || to < 0 || to >= len(siblings) {
+		return
+	}
+
+	cats := append([]Category(nil), m.categories...)
+	a, b := siblings[pos], siblings[to]
+	cats[a], cats[b] = cats[b], cats[a]
+	m.categories = cats
+	m.catIndex = b
+
+	prefs := m.catPrefs
+	prefs.Order = make([]string, len(cats))
+	for i, c := range cats {
+		prefs.Order[i] = c.ID
+	}
+	m.saveCategoryPrefs(prefs)
+	m.calculateLayout()
+}
+
+// toggleCollapsed folds or unfolds category ci in the sidebar.
+func (m *Model) toggleCollapsed(ci int) {
+	if !m.hasChildren(ci) {
+		return
+	}
+	id := m.categories[ci].ID
+	prefs := m.catPrefs
+	prefs.Collapsed = nil
+	for _, c := range m.catPrefs.Collapsed {
+		if c != id {
+			prefs.Collapsed = append(prefs.Collapsed, c)
+		}
+	}
+	if !m.catPrefs.isCollapsed(id) {
+		prefs.Collapsed = append(prefs.Collapsed, id)
+	}
+	m.saveCategoryPrefs(prefs)
+}
+
+// foldCurrent folds the current category, or the parent of a
+// subcategory, selecting the parent so the selection stays visible.
+func (m *Model) foldCurrent() {
+	ci := m.catIndex
+	if !m.hasChildren(ci) {
+		if ci = categoryIndex(m.categories, m.categories[ci].Parent); ci < 0 {
+			return
+		}
+	}
+	m.toggleCollapsed(ci)
+	if m.catPrefs.isCollapsed(m.categories[ci].ID) && ci != m.catIndex {
+		m.selectCategory(ci)
+	}
+}
+
+// toggleSidebar switches between the tab row and the tree sidebar.
+func (m *Model) toggleSidebar() {
+	prefs := m.catPrefs
+	prefs.Sidebar = !prefs.Sidebar
+	m.saveCategoryPrefs(prefs)
+	m.calculateLayout()
+	m.adjustScroll()
+}
+
+func (m *Model) saveCategoryPrefs(prefs categoryPrefs) {
+	m.catPrefs = prefs
+	if err := prefs.save(); err != nil {
+		m.showToast(fmt.Sprintf("Save failed: %v", err), "error")
+	}
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         SIDEBAR
+// ══════════════════════════════════════════════════════════════════
+
+// sidebarRows are the tree rows visible in the sidebar.
+func (m *Model) sidebarRows() []treeRow {
+	rows := m.categoryTree(false)
+	return rows[:min(len(rows), max(0, m.layout.ContentH-3))]
+}
+
+func (m *Model) viewSidebar() string {
+	cat := m.categories[m.catIndex]
+	height := m.layout.ContentH
+	inner := sidebarW - 3
+
+	var s strings.Builder
+	title := " 📂 Categories "
+	s.WriteString(gradientStr("╭─", m.gradientOf(cat)))
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text)).Bold(true).Render(title))
+	s.WriteString(gradientStr(strings.Repeat("─", max(0, inner-lipgloss.Width(title)-1))+"╮", m.gradientOf(cat)))
+	s.WriteString("\n")
+
+	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+	rows := m.sidebarRows()
+	visible := height - 3
+	for i := 0; i < visible; i++ {
+		line := ""
+		if i < len(rows) {
+			r := rows[i]
+			c := m.categories[r.Index]
+			fold := "  "
+			if m.hasChildren(r.Index) {
+				fold = "▾ "
+				if m.catPrefs.isCollapsed(c.ID) {
+					fold = "▸ "
+				}
+			}
+			count := countStyle.Render(fmt.Sprintf(" %d", len(m.categoryCommands(r.Index))))
+			label := strings.Repeat("  ", r.Depth) + fold + c.Icon + " " + c.Name
+			maxW := inner - 1 - lipgloss.Width(count)
+			if lipgloss.Width(label) > maxW {
+				label = truncateRunes(label, maxW-1) + "…"
+			}
+
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+			switch {
+			case r.Index == m.catIndex:
+				grad := getGradient(m.gradientOf(c))
+				style = lipgloss.NewStyle().
+					Background(lipgloss.Color(grad[0])).
+					Foreground(lipgloss.Color("#000000")).
+					Bold(true)
+			case r.Index == m.hoverCat:
+				style = lipgloss.NewStyle().
+					Background(lipgloss.Color(colors.surfaceHL)).
+					Foreground(lipgloss.Color(getGradient(m.gradientOf(c))[0]))
+			}
+			line = style.Render(label) + count
+		}
+		border := gradientStr("│", m.gradientOf(cat))
+		s.WriteString(border + lipgloss.NewStyle().Width(inner).Render(" "+line) + border + "\n")
+	}
+
+	s.WriteString(gradientStr("╰"+strings.Repeat("─", inner)+"╯", m.gradientOf(cat)))
+	return s.String()
+}
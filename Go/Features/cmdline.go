@@ -0,0 +1,659 @@
+// cmdline.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         COMMAND LINE
+// ══════════════════════════════════════════════════════════════════
+//
+// ":" opens a vim-style command line in the status bar. Commands may be
+// shortened to any unique prefix, Tab completes names and arguments, and
+// ↑/↓ walk the history, which is kept in cmdline.json.
+
+const (
+	cmdHistoryFile = "cmdline.json"
+	cmdHistoryMax  = 100
+)
+
+// exCommand is a command of the command line. Run gets the words after the
+// name; Force, when set, runs instead for "name!". Complete lists
+// candidates for the argument being typed.
+type exCommand struct {
+	Name     string
+	Args     string
+	Help     string
+	Run      func(m *Model, args []string) (tea.Cmd, error)
+	Force    func(m *Model, args []string) (tea.Cmd, error)
+	Complete func(m *Model) []string
+}
+
+var exCommands = []exCommand{
+	{Name: "cat", Args: "<category>", Help: "Jump to a category", Run: exCat, Complete: (*Model).categoryIDs},
+	{Name: "theme", Args: "[gradient]", Help: "Color every category with one gradient", Run: exTheme, Complete: func(*Model) []string { return themeNames() }},
+	{Name: "set", Args: "<option>=<value>...", Help: "Change a setting for this session", Run: exSet, Complete: func(*Model) []string { return setOptionNames() }},
+	{Name: "export", Args: "md|json [file]", Help: "Write the listed commands to a file; export! overwrites", Run: exExport(false), Force: exExport(true), Complete: func(*Model) []string { return []string{"md", "json"} }},
+	{Name: "search", Args: "[query]", Help: "Filter the list, or clear the filter", Run: exSearch},
+	{Name: "copy", Args: "[command]", Help: "Copy the command", Run: exItem(actCopy), Complete: (*Model).commandNames},
+	{Name: "example", Args: "[command]", Help: "Copy the example", Run: exItem(actExample), Complete: (*Model).commandNames},
+	{Name: "usage", Args: "[command]", Help: "Copy the usage", Run: exItem(actUsage), Complete: (*Model).commandNames},
+	{Name: "run", Args: "[command]", Help: "Run the command", Run: exItem(actRun), Complete: (*Model).commandNames},
+	{Name: "pin", Args: "[command]", Help: "Pin or unpin the command", Run: exItem(actPin), Complete: (*Model).commandNames},
+	{Name: "source", Args: "[command]", Help: "Show the profile source", Run: exItem(actSource), Complete: (*Model).commandNames},
+	{Name: "edit", Args: "[command]", Help: "Open the command in $EDITOR", Run: exItem(actEdit), Complete: (*Model).commandNames},
+	{Name: "note", Args: "[command]", Help: "Edit the note on the command", Run: exItem(actNote), Complete: (*Model).commandNames},
+	{Name: "new", Help: "Add a personal command", Run: func(m *Model, _ []string) (tea.Cmd, error) {
+		m.openCommandForm(false)
+		return textinput.Blink, nil
+	}},
+	{Name: "tags", Help: "Open the tag browser", Run: exScreen(screenTags)},
+	{Name: "workflows", Help: "Open the workflows", Run: exScreen(screenWorkflows)},
+	{Name: "composer", Help: "Open the pipeline composer", Run: exScreen(screenComposer)},
+	{Name: "conflicts", Help: "Show alias conflicts", Run: exScreen(screenConflicts)},
+	{Name: "suggest", Help: "Show history suggestions", Run: exScreen(screenSuggest)},
+	{Name: "learn", Help: "Start the quiz", Run: func(m *Model, _ []string) (tea.Cmd, error) {
+		return m.startQuiz(), nil
+	}},
+	{Name: "help", Help: "Show the keyboard controls", Run: func(m *Model, _ []string) (tea.Cmd, error) {
+		m.showHelp = true
+		return nil, nil
+	}},
+	{Name: "quit", Help: "Quit Features", Run: func(*Model, []string) (tea.Cmd, error) {
+		return tea.Quit, nil
+	}},
+}
+
+// commandLine is the open command line with its completion state.
+type commandLine struct {
+	Input   textinput.Model
+	Hist    int    // position in the history, len(history) for the draft
+	Draft   string // what was typed before walking the history
+	Matches []string
+	Match   int
+}
+
+type cmdHistoryStore struct {
+	History []string `json:"history"`
+}
+
+func loadCmdHistory() ([]string, error) {
+	var hs cmdHistoryStore
+	err := loadJSON(statePath(cmdHistoryFile), &hs)
+	return hs.History, err
+}
+
+func saveCmdHistory(history []string) error {
+	return saveJSON(statePath(cmdHistoryFile), cmdHistoryStore{History: history})
+}
+
+// openCommandLine opens the command line in the status bar.
+func (m *Model) openCommandLine() {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.CharLimit = 256
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent))
+	ti.Focus()
+	m.cmdline = &commandLine{Input: ti, Hist: len(m.cmdHistory)}
+}
+
+// handleCommandLineKey routes keys to the command line.
+func (m Model) handleCommandLineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cl := m.cmdline
+	key := msg.String()
+	if key != "tab" && key != "shift+tab" {
+		cl.Matches = nil
+	}
+	switch key {
+	case "esc", "ctrl+c":
+		m.cmdline = nil
+		return m, nil
+	case "backspace":
+		if cl.Input.Value() == "" {
+			m.cmdline = nil
+			return m, nil
+		}
+	case "enter":
+		line := strings.TrimSpace(cl.Input.Value())
+		m.cmdline = nil
+		if line == "" {
+			return m, nil
+		}
+		m.rememberCommandLine(line)
+		cmd, err := m.execCommandLine(line)
+		if err != nil {
+			m.showToast(fmt.Sprintf(":%s: %v", strings.Fields(line)[0], err), "error")
+		}
+		return m, cmd
+	case "tab", "shift+tab":
+		m.completeCommandLine(key == "tab")
+		return m, nil
+	case "up", "down":
+		m.walkCmdHistory(key == "up")
+		return m, nil
+	}
+	var cmd tea.Cmd
+	cl.Input, cmd = cl.Input.Update(msg)
+	return m, cmd
+}
+
+// rememberCommandLine appends line to the history, moving a repeated line
+// to the end.
+func (m *Model) rememberCommandLine(line string) {
+	history := make([]string, 0, len(m.cmdHistory)+1)
+	for _, h := range m.cmdHistory {
+		if h != line {
+			history = append(history, h)
+		}
+	}
+	history = append(history, line)
+	if len(history) > cmdHistoryMax {
+		history = history[len(history)-cmdHistoryMax:]
+	}
+	m.cmdHistory = history
+	if err := saveCmdHistory(history); err != nil {
+		m.showToast(fmt.Sprintf("History: %v", err), "error")
+	}
+}
+
+// walkCmdHistory steps to the previous (up) or next entry starting with
+// what was typed, like vim.
+func (m *Model) walkCmdHistory(up bool) {
+	cl := m.cmdline
+	if cl.Hist == len(m.cmdHistory) {
+		cl.Draft = cl.Input.Value()
+	}
+	for i := cl.Hist; ; {
+		if up {
+			i--
+		} else {
+			i++
+		}
+		if i < 0 {
+			return
+		}
+		if i >= len(m.cmdHistory) {
+			cl.Hist = len(m.cmdHistory)
+			cl.Input.SetValue(cl.Draft)
+			cl.Input.CursorEnd()
+			return
+		}
+		if strings.HasPrefix(m.cmdHistory[i], cl.Draft) {
+			cl.Hist = i
+			cl.Input.SetValue(m.cmdHistory[i])
+			cl.Input.CursorEnd()
+			return
+		}
+	}
+}
+
+// completeCommandLine completes the word being typed: the command name
+// first, then its argument. Repeated Tab cycles through the candidates.
+func (m *Model) completeCommandLine(forward bool) {
+	cl := m.cmdline
+	value := cl.Input.Value()
+	cut := strings.LastIndex(value, " ") + 1
+	head, word := value[:cut], value[cut:]
+
+	if cl.Matches == nil {
+		var candidates []string
+		if strings.TrimSpace(head) == "" {
+			for _, c := range exCommands {
+				candidates = append(candidates, c.Name)
+			}
+		} else if c, ok := lookupExCommand(strings.Fields(head)[0]); ok && c.Complete != nil {
+			candidates = c.Complete(m)
+		}
+		for _, s := range candidates {
+			if strings.HasPrefix(strings.ToLower(s), strings.ToLower(word)) {
+				cl.Matches = append(cl.Matches, s)
+			}
+		}
+		if len(cl.Matches) == 0 {
+			cl.Matches = nil
+			return
+		}
+		cl.Match = -1
+	}
+
+	n := len(cl.Matches)
+	if forward {
+		cl.Match = (cl.Match + 1) % n
+	} else {
+		cl.Match = (cl.Match - 1 + n) % n
+	}
+	done := cl.Matches[cl.Match]
+	if n == 1 {
+		// Nothing to cycle through; the next Tab completes the argument
+		cl.Matches = nil
+		if strings.TrimSpace(head) == "" {
+			done += " "
+		}
+	}
+	cl.Input.SetValue(head + done)
+	cl.Input.CursorEnd()
+}
+
+// matchExCommands returns the command named name, or else every command
+// it is a prefix of.
+func matchExCommands(name string) []exCommand {
+	name = strings.ToLower(name)
+	var found []exCommand
+	for _, c := range exCommands {
+		if c.Name == name {
+			return []exCommand{c}
+		}
+		if strings.HasPrefix(c.Name, name) {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// lookupExCommand finds a command by name or unique prefix, with or
+// without a trailing !.
+func lookupExCommand(name string) (exCommand, bool) {
+	if found := matchExCommands(strings.TrimSuffix(name, "!")); len(found) == 1 {
+		return found[0], true
+	}
+	return exCommand{}, false
+}
+
+// execCommandLine runs one command line.
+func (m *Model) execCommandLine(line string) (tea.Cmd, error) {
+	words := strings.Fields(line)
+	name, force := strings.CutSuffix(words[0], "!")
+	if name == "q" {
+		return tea.Quit, nil
+	}
+	found := matchExCommands(name)
+	switch len(found) {
+	case 0:
+		return nil, errors.New("not a command")
+	case 1:
+		if !force {
+			return found[0].Run(m, words[1:])
+		}
+		if found[0].Force == nil {
+			return nil, errors.New("no ! allowed")
+		}
+		return found[0].Force(m, words[1:])
+	}
+	names := make([]string, len(found))
+	for i, c := range found {
+		names[i] = c.Name
+	}
+	return nil, fmt.Errorf("ambiguous, could be %s", strings.Join(names, ", "))
+}
+
+// ── Commands ─────────────────────────────────────────────────────
+
+func exCat(m *Model, args []string) (tea.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errors.New("usage: cat <category>")
+	}
+	ci := m.findCategory(strings.Join(args, " "))
+	if ci < 0 {
+		return nil, errors.New("no category matches")
+	}
+	m.selectCategory(ci)
+	return nil, nil
+}
+
+func exTheme(m *Model, args []string) (tea.Cmd, error) {
+	if len(args) == 0 || args[0] == "default" {
+		m.theme = ""
+		m.showToast("Theme: category colors", "info")
+		return nil, nil
+	}
+	name := strings.ToLower(args[0])
+	for _, t := range themeNames() {
+		if t == name {
+			m.theme = name
+			m.showToast("Theme: "+name, "info")
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown theme %q", args[0])
+}
+
+// themeNames lists the gradients usable as a theme.
+func themeNames() []string {
+	var names []string
+	for name := range gradients {
+		names = append(names, name)
+	}
+	for name := range enhancedGradients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{"default"}, names...)
+}
+
+// setOptions are the settings :set changes, as option=value; a bare option
+// turns a boolean on and no<option> turns it off.
+var setOptions = []struct {
+	Name string
+	Bool bool
+	Set  func(m *Model, value string) error
+}{
+	{"split", false, func(m *Model, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < minSplitRatio || n > maxSplitRatio {
+			return fmt.Errorf("split must be between %d and %d", minSplitRatio, maxSplitRatio)
+		}
+		m.splitRatio = n
+		m.calculateLayout()
+		m.adjustScroll()
+		return nil
+	}},
+	{"tick", false, func(m *Model, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		c := m.config
+		c.Tick = duration(d)
+		if err := c.validate(); err != nil {
+			return err
+		}
+		m.setConfig(c)
+		return nil
+	}},
+	{"lang", false, func(m *Model, v string) error {
+		if !isKnownLocale(v) {
+			return fmt.Errorf("lang must be one of %s", localeCodes())
+		}
+		m.locale = v // for this session only; L remembers its choice
+		return nil
+	}},
+	{"risk", false, func(m *Model, v string) error {
+		if v == "all" {
+			v = ""
+		}
+		if !validLevel(riskLevels, v) {
+			return fmt.Errorf("unknown risk %q", v)
+		}
+		m.riskFilter = v
+		m.resetSelection()
+		return nil
+	}},
+	{"allos", true, func(m *Model, v string) error {
+		if (v == "on") != m.showAllOS {
+			m.toggleAllPlatforms()
+		}
+		return nil
+	}},
+	{"sidebar", true, func(m *Model, v string) error {
+		if (v == "on") != (m.layout.SideW > 0) {
+			m.toggleSidebar()
+		}
+		return nil
+	}},
+}
+
+// setOptionNames lists the options for completion.
+func setOptionNames() []string {
+	var names []string
+	for _, o := range setOptions {
+		if o.Bool {
+			names = append(names, o.Name, "no"+o.Name)
+		} else {
+			names = append(names, o.Name+"=")
+		}
+	}
+	return names
+}
+
+func exSet(m *Model, args []string) (tea.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errors.New("usage: set <option>=<value>")
+	}
+	for _, arg := range args {
+		name, value, hasValue := strings.Cut(arg, "=")
+		applied := false
+		for _, o := range setOptions {
+			switch {
+			case o.Bool && !hasValue && name == o.Name:
+				value = "on"
+			case o.Bool && !hasValue && name == "no"+o.Name:
+				value = "off"
+			case o.Bool && hasValue && name == o.Name:
+				switch value {
+				case "on", "true", "1":
+					value = "on"
+				case "off", "false", "0":
+					value = "off"
+				default:
+					return nil, fmt.Errorf("%s is on or off", o.Name)
+				}
+			case !o.Bool && name == o.Name:
+				if !hasValue {
+					return nil, fmt.Errorf("usage: set %s=<value>", o.Name)
+				}
+			default:
+				continue
+			}
+			if err := o.Set(m, strings.TrimSpace(value)); err != nil {
+				return nil, err
+			}
+			applied = true
+			break
+		}
+		if !applied {
+			return nil, fmt.Errorf("unknown option %q", name)
+		}
+	}
+	m.showToast("Set "+strings.Join(args, " "), "info")
+	return nil, nil
+}
+
+func exSearch(m *Model, args []string) (tea.Cmd, error) {
+	m.searchInput.SetValue(strings.Join(args, " "))
+	m.updateFiltered()
+	m.itemIndex = 0
+	m.scrollY = 0
+	return nil, nil
+}
+
+// exItem runs an item action on the named command, or the selected one.
+func exItem(action string) func(m *Model, args []string) (tea.Cmd, error) {
+	return func(m *Model, args []string) (tea.Cmd, error) {
+		if len(args) == 0 {
+			if m.itemIndex >= len(m.filtered) {
+				return nil, errors.New("no command selected")
+			}
+			return m.itemAction(action, m.filtered[m.itemIndex]), nil
+		}
+		c, ok := m.findCommand(args[0])
+		if !ok {
+			return nil, fmt.Errorf("no command %q", args[0])
+		}
+		return m.itemAction(action, c), nil
+	}
+}
+
+func exScreen(screen string) func(m *Model, args []string) (tea.Cmd, error) {
+	return func(m *Model, _ []string) (tea.Cmd, error) {
+		m.setScreen(screen)
+		return nil, nil
+	}
+}
+
+// findCommand looks a command up by name, in the list first and then in
+// every category.
+func (m *Model) findCommand(name string) (Command, bool) {
+	for _, c := range m.filtered {
+		if c.Cmd == name {
+			return c, true
+		}
+	}
+	for _, cat := range m.categories {
+		for _, c := range cat.Commands {
+			if c.Cmd == name {
+				return c, true
+			}
+		}
+	}
+	return Command{}, false
+}
+
+// commandNames lists every catalog command once, sorted.
+func (m *Model) commandNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, cat := range m.categories {
+		for _, c := range cat.Commands {
+			if !seen[c.Cmd] {
+				seen[c.Cmd] = true
+				names = append(names, c.Cmd)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// categoryIDs lists the category IDs in tree order.
+func (m *Model) categoryIDs() []string {
+	var ids []string
+	for _, r := range m.categoryTree(true) {
+		ids = append(ids, m.categories[r.Index].ID)
+	}
+	return ids
+}
+
+// ── Export ───────────────────────────────────────────────────────
+
+// exExport writes the listed commands as Markdown or as a catalog file
+// to the given path, or under the state dir. A file that already exists
+// is only replaced when force is set, as with :export!.
+func exExport(force bool) func(m *Model, args []string) (tea.Cmd, error) {
+	return func(m *Model, args []string) (tea.Cmd, error) {
+		if len(args) == 0 || len(args) > 2 {
+			return nil, errors.New("usage: export[!] md|json [file]")
+		}
+		path := filepath.Join(stateDir(), "export", m.categories[m.catIndex].ID+"."+args[0])
+		if len(args) == 2 {
+			path = args[1]
+		}
+		if _, err := os.Stat(path); err == nil && !force {
+			return nil, fmt.Errorf("%s exists (add ! to overwrite)", path)
+		}
+		return nil, m.exportTo(path, args[0])
+	}
+}
+
+// exportTo writes the listed commands to path in the given format.
+func (m *Model) exportTo(path, format string) error {
+	cat := m.categories[m.catIndex]
+	var data []byte
+	switch format {
+	case "md":
+		data = []byte(m.exportMarkdown(cat))
+	case "json":
+		f := catalogFile{SchemaVersion: catalogSchemaVersion, Name: cat.Name}
+		for _, c := range m.filtered {
+			owner := m.categories[m.ownerOf(c.Cmd)]
+			f.Commands = append(f.Commands, entryFromCommand(owner.ID, owner.Name, c))
+		}
+		var err error
+		if data, err = json.MarshalIndent(f, "", "  "); err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unknown format %q (md or json)", format)
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %v", err)
+	}
+	m.showToast(fmt.Sprintf("Exported %d commands to %s", len(m.filtered), path), "success")
+	return nil
+}
+
+// exportMarkdown renders the listed commands as a Markdown cheat sheet.
+func (m *Model) exportMarkdown(cat Category) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", m.categoryPath(m.catIndex))
+	for _, c := range m.filtered {
+		fmt.Fprintf(&b, "\n## `%s`\n\n%s\n", c.Cmd, c.Description(m.locale))
+		if usage := c.UsageText(m.locale); usage != "" {
+			fmt.Fprintf(&b, "\n**Usage:** `%s`\n", usage)
+		}
+		if c.Example != "" {
+			fmt.Fprintf(&b, "\n**Example:** `%s`\n", c.Example)
+		}
+		if note := strings.TrimSpace(m.notes[c.Cmd]); note != "" {
+			// Quoted so a heading or list in the note stays inside this
+			// command's section.
+			b.WriteString("\n")
+			for _, line := range strings.Split(strings.ReplaceAll(note, "\r\n", "\n"), "\n") {
+				b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// ── View ─────────────────────────────────────────────────────────
+
+// viewCommandLine replaces the status bar while the command line is open,
+// listing the completion candidates or the matching commands.
+func (m *Model) viewCommandLine() string {
+	cl := m.cmdline
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+	hl := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+
+	var hint string
+	if cl.Matches != nil {
+		parts := make([]string, len(cl.Matches))
+		for i, s := range cl.Matches {
+			if i == cl.Match {
+				parts[i] = hl.Render(s)
+			} else {
+				parts[i] = muted.Render(s)
+			}
+		}
+		hint = strings.Join(parts, " ")
+	} else if words := strings.Fields(cl.Input.Value()); len(words) > 0 {
+		if c, ok := lookupExCommand(words[0]); ok {
+			hint = muted.Render(strings.TrimSpace(c.Name+" "+c.Args) + " — " + c.Help)
+		}
+	}
+
+	input := cl.Input.View()
+	line := "  " + input
+	if hint != "" {
+		room := m.width - lipgloss.Width(line) - 6
+		if room > 10 {
+			line += "   " + ansi.Truncate(hint, room, "…")
+		}
+	}
+	return lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Width(m.width).
+		MaxHeight(1).
+		Render(line)
+}
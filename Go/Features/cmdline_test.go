@@ -0,0 +1,154 @@
+// cmdline_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMatchExCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"cat", []string{"cat"}},
+		{"CAT", []string{"cat"}},
+		{"th", []string{"theme"}},
+		{"co", []string{"copy", "composer", "conflicts"}},
+		{"s", []string{"set", "search", "source", "suggest"}},
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, c := range matchExCommands(tt.name) {
+			got = append(got, c.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchExCommands(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if c, ok := lookupExCommand("exp!"); !ok || c.Name != "export" {
+		t.Errorf("lookupExCommand(exp!) = %q, %v", c.Name, ok)
+	}
+}
+
+func TestExecCommandLine(t *testing.T) {
+	m := newTestModel(t)
+	tests := []struct {
+		line string
+		err  string
+	}{
+		{"zzz", "not a command"},
+		{"co", "ambiguous, could be copy, composer, conflicts"},
+		{"cat!", "no ! allowed"},
+		{"cat", "usage: cat <category>"},
+	}
+	for _, tt := range tests {
+		if _, err := m.execCommandLine(tt.line); err == nil || err.Error() != tt.err {
+			t.Errorf("execCommandLine(%q) error = %v, want %s", tt.line, err, tt.err)
+		}
+	}
+	for _, line := range []string{"q", "q!", "quit"} {
+		if cmd, err := m.execCommandLine(line); err != nil || cmd == nil {
+			t.Errorf("execCommandLine(%q) = %v, %v, want quit", line, cmd, err)
+		} else if _, ok := cmd().(tea.QuitMsg); !ok {
+			t.Errorf("execCommandLine(%q) does not quit", line)
+		}
+	}
+}
+
+// newTestModel returns a model with its state in a temporary directory.
+func newTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("FEATURES_HOME", t.TempDir())
+	m := newModel()
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return next.(Model)
+}
+
+func TestExSet(t *testing.T) {
+	m := newTestModel(t)
+	sidebar := m.layout.SideW > 0
+	if _, err := m.execCommandLine("set split=30 tick=100ms risk=caution lang=vi noallos"); err != nil {
+		t.Fatal(err)
+	}
+	if m.splitRatio != 30 || time.Duration(m.config.Tick) != 100*time.Millisecond ||
+		m.riskFilter != riskCaution || m.locale != "vi" || m.showAllOS {
+		t.Errorf("set did not apply: split %d, tick %v, risk %q, lang %q, allos %v",
+			m.splitRatio, time.Duration(m.config.Tick), m.riskFilter, m.locale, m.showAllOS)
+	}
+	if saved := savedLocale(); saved != "" {
+		t.Errorf("set lang was remembered as %q, want this session only", saved)
+	}
+	if _, err := m.execCommandLine("set allos risk=all"); err != nil || !m.showAllOS || m.riskFilter != "" {
+		t.Errorf("set allos risk=all: %v, allos %v, risk %q", err, m.showAllOS, m.riskFilter)
+	}
+	on := "sidebar=off"
+	if !sidebar {
+		on = "sidebar=on"
+	}
+	if _, err := m.execCommandLine("set " + on); err != nil || (m.layout.SideW > 0) == sidebar {
+		t.Errorf("set %s: %v, sidebar still %v", on, err, sidebar)
+	}
+
+	for line, want := range map[string]string{
+		"set":             "usage: set <option>=<value>",
+		"set split":       "usage: set split=<value>",
+		"set split=95":    "split must be between 20 and 80",
+		"set tick=soon":   `time: invalid duration "soon"`,
+		"set lang=xx":     "lang must be one of en, vi",
+		"set risk=scary":  `unknown risk "scary"`,
+		"set allos=maybe": "allos is on or off",
+		"set color=red":   `unknown option "color"`,
+	} {
+		if _, err := m.execCommandLine(line); err == nil || err.Error() != want {
+			t.Errorf("%s: error = %v, want %s", line, err, want)
+		}
+	}
+	if m.splitRatio != 30 {
+		t.Errorf("a failed set changed split to %d", m.splitRatio)
+	}
+}
+
+func TestExExportOverwrite(t *testing.T) {
+	m := newTestModel(t)
+	path := filepath.Join(t.TempDir(), "git.md")
+	if err := os.WriteFile(path, []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := m.execCommandLine("export md " + path)
+	if err == nil || !strings.Contains(err.Error(), "add ! to overwrite") {
+		t.Errorf("export over a file: error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "mine" {
+		t.Errorf("export replaced the file without !")
+	}
+
+	if _, err := m.execCommandLine("export! md " + path); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "# ") {
+		t.Errorf("export! wrote %q", data)
+	}
+
+	// The default file under the state dir follows the same rule.
+	if _, err := m.execCommandLine("export json"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.execCommandLine("export json"); err == nil || !strings.Contains(err.Error(), "add ! to overwrite") {
+		t.Errorf("export over the default file: error = %v", err)
+	}
+	if _, err := m.execCommandLine("export! json"); err != nil {
+		t.Error(err)
+	}
+	if _, err := m.execCommandLine("export csv " + filepath.Join(t.TempDir(), "x.csv")); err == nil {
+		t.Error("export accepted csv")
+	}
+}
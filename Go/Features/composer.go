@@ -0,0 +1,165 @@
+// composer.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         PIPELINE COMPOSER
+// ══════════════════════════════════════════════════════════════════
+
+// joiners are the separators the composer can join steps with.
+var joiners = []string{";", "&&", "|"}
+
+// isComposed reports whether cmd is in the composer tray.
+func (m *Model) isComposed(cmd string) bool {
+	return m.composedIndex(cmd) >= 0
+}
+
+func (m *Model) composedIndex(cmd string) int {
+	for i, c := range m.composer {
+		if c.Cmd == cmd {
+			return i
+		}
+	}
+	return -1
+}
+
+// toggleComposed adds or removes c from the composer tray.
+func (m *Model) toggleComposed(c Command) {
+	if i := m.composedIndex(c.Cmd); i >= 0 {
+		m.composer = append(m.composer[:i:i], m.composer[i+1:]...)
+	} else {
+		m.composer = append(m.composer, c)
+	}
+	m.calculateLayout()
+}
+
+// composedLine joins the tray with the current joiner.
+func (m *Model) composedLine() string {
+	parts := make([]string, len(m.composer))
+	for i, c := range m.composer {
+		parts[i] = c.Cmd
+	}
+	return strings.Join(parts, " "+joiners[m.joiner]+" ")
+}
+
+func (m Model) handleComposerKey(key string) (tea.Model, tea.Cmd) {
+	n := len(m.composer)
+	switch key {
+	case "up", "k":
+		m.movePanel(-1, n)
+	case "down", "j":
+		m.movePanel(1, n)
+	case "K", "shift+up":
+		if i := m.panelIndex; i > 0 && i < n {
+			m.composer[i-1], m.composer[i] = m.composer[i], m.composer[i-1]
+			m.movePanel(-1, n)
+		}
+	case "J", "shift+down":
+		if i := m.panelIndex; i < n-1 {
+			m.composer[i+1], m.composer[i] = m.composer[i], m.composer[i+1]
+			m.movePanel(1, n)
+		}
+	case "x", "delete":
+		if m.panelIndex < n {
+			m.toggleComposed(m.composer[m.panelIndex])
+			m.movePanel(0, len(m.composer))
+		}
+	case "X":
+		m.composer = nil
+		m.calculateLayout()
+		m.setScreen(screenCatalog)
+		m.showToast("Composer cleared", "info")
+	case "s":
+		if n > 0 {
+			wf := Workflow{}
+			for _, c := range m.composer {
+				wf.Steps = append(wf.Steps, c.Cmd)
+			}
+			m.openWorkflowForm(wf)
+			return m, textinput.Blink
+		}
+	case "o", "tab":
+		m.joiner = (m.joiner + 1) % len(joiners)
+	case "enter", "y":
+		if n > 0 {
+			m.copyText(m.composedLine(), m.composer[0].Cmd)
+		}
+	case "r":
+		if n > 0 {
+			line := m.composedLine()
+			return m, m.confirmRisky(line, m.composer, func(*Model) tea.Cmd {
+				return runLine(line)
+			})
+		}
+	case "esc", "c":
+		m.setScreen(screenCatalog)
+	}
+	return m, nil
+}
+
+func (m *Model) viewComposer() string {
+	numStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Width(5)
+	cmdStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+	joinStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning)).Bold(true)
+
+	rows := make([]string, 0, len(m.composer)+2)
+	for i, c := range m.composer {
+		join := ""
+		if i < len(m.composer)-1 {
+			join = " " + joinStyle.Render(joiners[m.joiner])
+		}
+		rows = append(rows, fmt.Sprintf(" %s%s%s  %s",
+			numStyle.Render(fmt.Sprintf("%d.", i+1)),
+			cmdStyle.Render(c.Cmd), join,
+			descStyle.Render(c.Description(m.locale))))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.textMuted)).
+			Italic(true).
+			Render("  Select commands with Space or Shift+Click to compose them"))
+	} else {
+		rows = append(rows, "", " "+lipgloss.NewStyle().
+			Background(lipgloss.Color(colors.bgDark)).
+			Foreground(lipgloss.Color(colors.success)).
+			Padding(0, 1).
+			Render("$ "+m.composedLine()))
+	}
+
+	footer := "K/J reorder • x remove • o joiner • y copy • r run • s save workflow • esc back"
+	return m.renderPanel("🧩 Composer", "plasma", rows, m.panelIndex, footer)
+}
+
+// viewTray renders the one-line composer summary shown above the status bar.
+func (m *Model) viewTray() string {
+	if len(m.composer) == 0 {
+		return ""
+	}
+	label := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.secondary)).
+		Foreground(lipgloss.Color("#000000")).
+		Bold(true).
+		Padding(0, 1).
+		Render(fmt.Sprintf("🧩 %d", len(m.composer)))
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.textMuted)).
+		Render("  c: compose")
+
+	maxW := m.width - m.layout.Padding*2 - lipgloss.Width(label) - lipgloss.Width(hint) - 2
+	line := m.composedLine()
+	if lipgloss.Width(line) > maxW {
+		line = truncateRunes(line, maxW-1) + "…"
+	}
+	body := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success)).Render(" " + line)
+
+	return strings.Repeat(" ", m.layout.Padding) + label + body + hint + "\n"
+}
@@ -0,0 +1,291 @@
+// config.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         CONFIGURATION
+// ══════════════════════════════════════════════════════════════════
+//
+// Settings are read from config.json in the state directory, or from the
+// file given with --config or FEATURES_CONFIG, and then overridden by
+// FEATURES_* environment variables. The file is checked every few seconds
+// and reloaded when it changes.
+
+const (
+	configFile      = "config.json"
+	configPollEvery = 2 * time.Second
+)
+
+// configOverride is the file given with --config.
+var configOverride string
+
+// configPath returns the config file in use.
+func configPath() string {
+	if configOverride != "" {
+		return configOverride
+	}
+	if p := os.Getenv("FEATURES_CONFIG"); p != "" {
+		return p
+	}
+	return statePath(configFile)
+}
+
+// duration is a time.Duration written as "80ms" in JSON.
+type duration time.Duration
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.New("durations are strings such as \"80ms\" or \"2s\"")
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+// toastConfig holds how long each kind of toast stays up.
+type toastConfig struct {
+	Info    duration `json:"info"`
+	Success duration `json:"success"`
+	Warning duration `json:"warning"`
+	Error   duration `json:"error"`
+	Confirm duration `json:"confirm"` // y/n prompts
+}
+
+// appConfig is the content of config.json.
+type appConfig struct {
+	Tick              duration    `json:"tick"`
+	SearchCharLimit   int         `json:"searchCharLimit"`
+	SearchPlaceholder string      `json:"searchPlaceholder"`
+	SplitRatio        int         `json:"splitRatio"` // list share of the content, in percent
+	DefaultCategory   string      `json:"defaultCategory,omitempty"`
+	Locale            string      `json:"locale,omitempty"` // see detectLocale
+	Toasts            toastConfig `json:"toasts"`
+}
+
+func defaultConfig() appConfig {
+	return appConfig{
+		Tick:              duration(80 * time.Millisecond),
+		SearchCharLimit:   64,
+		SearchPlaceholder: "✨ Type to search commands...",
+		SplitRatio:        defaultSplitRatio,
+		Toasts: toastConfig{
+			Info:    duration(2400 * time.Millisecond),
+			Success: duration(2400 * time.Millisecond),
+			Warning: duration(2400 * time.Millisecond),
+			Error:   duration(2400 * time.Millisecond),
+			Confirm: duration(8 * time.Second),
+		},
+	}
+}
+
+// loadConfig reads the config at path over the defaults and applies the
+// environment. A missing file is not an error. mod is the file's
+// modification time, zero when there is none.
+func loadConfig(path string) (c appConfig, mod time.Time, err error) {
+	c = defaultConfig()
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return c, mod, err
+	default:
+		if info, err := os.Stat(path); err == nil {
+			mod = info.ModTime()
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&c); err != nil {
+			return defaultConfig(), mod, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := c.applyEnv(); err != nil {
+		return defaultConfig(), mod, err
+	}
+	if err := c.validate(); err != nil {
+		return defaultConfig(), mod, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, mod, nil
+}
+
+// applyEnv overrides settings from FEATURES_* variables.
+func (c *appConfig) applyEnv() error {
+	vars := []struct {
+		name string
+		dst  interface{}
+	}{
+		{"FEATURES_TICK", &c.Tick},
+		{"FEATURES_SEARCH_CHAR_LIMIT", &c.SearchCharLimit},
+		{"FEATURES_SEARCH_PLACEHOLDER", &c.SearchPlaceholder},
+		{"FEATURES_SPLIT_RATIO", &c.SplitRatio},
+		{"FEATURES_DEFAULT_CATEGORY", &c.DefaultCategory},
+		{"FEATURES_TOAST_INFO", &c.Toasts.Info},
+		{"FEATURES_TOAST_SUCCESS", &c.Toasts.Success},
+		{"FEATURES_TOAST_WARNING", &c.Toasts.Warning},
+		{"FEATURES_TOAST_ERROR", &c.Toasts.Error},
+		{"FEATURES_TOAST_CONFIRM", &c.Toasts.Confirm},
+	}
+	for _, v := range vars {
+		s := os.Getenv(v.name)
+		if s == "" {
+			continue
+		}
+		switch dst := v.dst.(type) {
+		case *string:
+			*dst = s
+		case *int:
+			n, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return fmt.Errorf("%s: %q is not a number", v.name, s)
+			}
+			*dst = n
+		case *duration:
+			d, err := time.ParseDuration(strings.TrimSpace(s))
+			if err != nil {
+				return fmt.Errorf("%s: %v", v.name, err)
+			}
+			*dst = duration(d)
+		}
+	}
+	return nil
+}
+
+// validate checks that every setting is in range. The default category is
+// checked against the catalog once it is loaded.
+func (c appConfig) validate() error {
+	if t := time.Duration(c.Tick); t < 10*time.Millisecond || t > time.Second {
+		return fmt.Errorf("tick must be between 10ms and 1s, got %s", t)
+	}
+	if c.SearchCharLimit < 1 || c.SearchCharLimit > 1024 {
+		return fmt.Errorf("searchCharLimit must be between 1 and 1024, got %d", c.SearchCharLimit)
+	}
+	if c.Locale != "" && !isKnownLocale(c.Locale) {
+		return fmt.Errorf("locale must be one of %s, got %q", localeCodes(), c.Locale)
+	}
+	if c.SplitRatio < minSplitRatio || c.SplitRatio > maxSplitRatio {
+		return fmt.Errorf("splitRatio must be between %d and %d, got %d", minSplitRatio, maxSplitRatio, c.SplitRatio)
+	}
+	toasts := []struct {
+		name string
+		d    duration
+	}{
+		{"info", c.Toasts.Info},
+		{"success", c.Toasts.Success},
+		{"warning", c.Toasts.Warning},
+		{"error", c.Toasts.Error},
+		{"confirm", c.Toasts.Confirm},
+	}
+	for _, t := range toasts {
+		if d := time.Duration(t.d); d < 100*time.Millisecond || d > 5*time.Minute {
+			return fmt.Errorf("toasts.%s must be between 100ms and 5m, got %s", t.name, d)
+		}
+	}
+	return nil
+}
+
+// toastTicks converts the duration for a toast kind into animation ticks.
+func (c appConfig) toastTicks(kind string) int {
+	d := c.Toasts.Info
+	switch kind {
+	case "success":
+		d = c.Toasts.Success
+	case "warning":
+		d = c.Toasts.Warning
+	case "error":
+		d = c.Toasts.Error
+	case "confirm":
+		d = c.Toasts.Confirm
+	}
+	return max(1, int(time.Duration(d)/time.Duration(c.Tick)))
+}
+
+// setConfig applies c to the running model. A changed locale takes effect
+// right away.
+func (m *Model) setConfig(c appConfig) {
+	if c.Locale != "" && c.Locale != m.config.Locale {
+		m.locale = c.Locale
+	}
+	m.config = c
+	m.searchInput.CharLimit = c.SearchCharLimit
+	m.searchInput.Placeholder = c.SearchPlaceholder
+	m.calculateLayout()
+}
+
+// ── Live reload ──────────────────────────────────────────────────
+
+// configReloadMsg reports a config check; Changed is false when the file
+// did not change since the last one.
+type configReloadMsg struct {
+	Changed bool
+	Config  appConfig
+	Mod     time.Time
+	Err     error
+}
+
+// configPollCmd checks the config file after configPollEvery and reloads
+// it when its modification time differs from mod.
+func configPollCmd(path string, mod time.Time) tea.Cmd {
+	return tea.Tick(configPollEvery, func(time.Time) tea.Msg {
+		var cur time.Time
+		if info, err := os.Stat(path); err == nil {
+			cur = info.ModTime()
+		}
+		if cur.Equal(mod) {
+			return configReloadMsg{}
+		}
+		c, cur, err := loadConfig(path)
+		return configReloadMsg{Changed: true, Config: c, Mod: cur, Err: err}
+	})
+}
+
+// handleConfigReload applies a reloaded config, keeping the current one
+// when the new file is invalid.
+func (m *Model) handleConfigReload(msg configReloadMsg) {
+	m.configMod = msg.Mod
+	if msg.Err != nil {
+		m.showToast(fmt.Sprintf("Config: %v", msg.Err), "error")
+		return
+	}
+	m.setConfig(msg.Config)
+	m.showToast("Config reloaded", "info")
+}
+
+// runConfig prints the effective configuration, or the validation error.
+func runConfig(args []string) error {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.StringVar(&configOverride, "config", "", "config file (default "+statePath(configFile)+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	c, _, err := loadConfig(configPath())
+	if err != nil {
+		return err
+	}
+	if c.DefaultCategory != "" && categoryIndex(newModel().categories, c.DefaultCategory) < 0 {
+		return fmt.Errorf("unknown default category %q", c.DefaultCategory)
+	}
+	fmt.Fprintf(os.Stderr, "# %s\n", configPath())
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
@@ -0,0 +1,195 @@
+// form.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         FORM OVERLAY
+// ══════════════════════════════════════════════════════════════════
+
+// Form kinds, dispatched on submit.
+const (
+	formCommand        = "command"
+	formPlaceholder    = "placeholder"
+	formPlaceholderRun = "placeholder-run"
+	formWorkflow       = "workflow"
+	formWorkflowRun    = "workflow-run"
+	formJump           = "jump"
+)
+
+type formField struct {
+	Label string
+	Input textinput.Model
+}
+
+// inputForm is a modal overlay of labelled text inputs.
+type inputForm struct {
+	Title  string
+	Kind   string
+	Fields []formField
+	Focus  int
+	Err    string
+
+	// Context for the submit handler
+	OrigCat  string
+	OrigCmd  string
+	Target   Command
+	Workflow Workflow
+}
+
+func newFormField(label, value, placeholder string) formField {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 256
+	ti.Width = 48
+	ti.SetValue(value)
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary))
+	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent))
+	return formField{Label: label, Input: ti}
+}
+
+func newInputForm(title, kind string, fields ...formField) *inputForm {
+	f := &inputForm{Title: title, Kind: kind, Fields: fields}
+	f.focusField(0)
+	return f
+}
+
+// value returns the trimmed value of the field with the given label.
+func (f *inputForm) value(label string) string {
+	for _, fld := range f.Fields {
+		if fld.Label == label {
+			return strings.TrimSpace(fld.Input.Value())
+		}
+	}
+	return ""
+}
+
+func (f *inputForm) focusField(i int) {
+	if len(f.Fields) == 0 {
+		return
+	}
+	i = (i + len(f.Fields)) % len(f.Fields)
+	for j := range f.Fields {
+		if j == i {
+			f.Fields[j].Input.Focus()
+		} else {
+			f.Fields[j].Input.Blur()
+		}
+	}
+	f.Focus = i
+}
+
+// handleFormKey routes keys to the open form. Submitting calls submitForm.
+func (m Model) handleFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.form
+	switch msg.String() {
+	case "esc":
+		m.form = nil
+		return m, nil
+	case "tab", "down":
+		f.focusField(f.Focus + 1)
+		return m, textinput.Blink
+	case "shift+tab", "up":
+		f.focusField(f.Focus - 1)
+		return m, textinput.Blink
+	case "ctrl+s":
+		return m.submitForm()
+	case "enter":
+		if f.Focus < len(f.Fields)-1 {
+			f.focusField(f.Focus + 1)
+			return m, textinput.Blink
+		}
+		return m.submitForm()
+	}
+
+	var cmd tea.Cmd
+	f.Fields[f.Focus].Input, cmd = f.Fields[f.Focus].Input.Update(msg)
+	f.Err = ""
+	if f.Kind == formWorkflow {
+		f.growSteps()
+	}
+	return m, cmd
+}
+
+// submitForm validates and applies the open form. On a validation error the
+// form stays open with Err set.
+func (m Model) submitForm() (tea.Model, tea.Cmd) {
+	var err error
+	switch m.form.Kind {
+	case formCommand:
+		err = m.saveCommandForm()
+	case formPlaceholder:
+		err = m.submitPlaceholderForm()
+	case formPlaceholderRun:
+		line := m.placeholderLine()
+		m.form = nil
+		return m, runLine(line)
+	case formWorkflow:
+		err = m.saveWorkflowForm()
+	case formWorkflowRun:
+		err = m.submitWorkflowRunForm()
+	case formJump:
+		err = m.submitJumpForm()
+	}
+	if err != nil {
+		m.form.Err = err.Error()
+		return m, nil
+	}
+	m.form = nil
+	return m, nil
+}
+
+func (m Model) viewForm() string {
+	f := m.form
+	width := 64
+
+	var b strings.Builder
+	b.WriteString(gradientStr(fmt.Sprintf("✦ %s ✦", f.Title), "aurora") + "\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim)).Width(14)
+	activeLabel := labelStyle.Foreground(lipgloss.Color(colors.primary)).Bold(true)
+
+	for i, fld := range f.Fields {
+		ls := labelStyle
+		if i == f.Focus {
+			ls = activeLabel
+		}
+		b.WriteString(ls.Render(fld.Label) + " " + fld.Input.View() + "\n")
+	}
+
+	if f.Err != "" {
+		b.WriteString("\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.error)).
+			Bold(true).
+			Render("✗ "+f.Err) + "\n")
+	}
+
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.textMuted)).
+		Italic(true).
+		Render("Tab/↑↓ move • Enter next/save • Ctrl+S save • Esc cancel")
+	b.WriteString("\n" + hint)
+
+	box := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.secondary)).
+		Padding(1, 2).
+		Width(width).
+		Render(b.String())
+
+	return lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color("#000000")),
+	)
+}
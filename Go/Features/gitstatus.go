@@ -0,0 +1,367 @@
+// gitstatus.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         GIT REPOSITORY STATUS
+// ══════════════════════════════════════════════════════════════════
+
+const (
+	gitTimeout      = 3 * time.Second
+	gitRefreshEvery = 5 * time.Second
+	gitMaxFiles     = 5 // files listed per group in the detail pane
+)
+
+// gitStatus is a snapshot of the repository in the working directory.
+type gitStatus struct {
+	Loaded    bool
+	Branch    string
+	Upstream  string
+	Ahead     int
+	Behind    int
+	Staged    []string
+	Unstaged  []string
+	Untracked []string
+	Stashes   []string
+	Branches  []gitBranch // local branches first, then remote
+	Err       error
+}
+
+// gitBranch is a local or remote-tracking branch.
+type gitBranch struct {
+	Name   string // short name, "origin/main" for remote ones
+	Remote bool
+}
+
+type gitStatusMsg gitStatus
+
+// gitRefreshMsg triggers a periodic status refresh.
+type gitRefreshMsg struct{}
+
+func gitRefreshTick() tea.Cmd {
+	return tea.Tick(gitRefreshEvery, func(time.Time) tea.Msg { return gitRefreshMsg{} })
+}
+
+// git runs a git subcommand in dir and returns its output lines.
+func git(dir string, args ...string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(string(out), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// loadGitStatusCmd reads the status of the repository at root.
+func loadGitStatusCmd(root string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := git(root, "status", "--porcelain=v2", "--branch")
+		if err != nil {
+			return gitStatusMsg{Loaded: true, Err: err}
+		}
+		st := parseGitStatus(lines)
+		st.Stashes, _ = git(root, "stash", "list", "--format=%gd: %s")
+		refs, _ := git(root, "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+		st.Branches = parseBranches(refs)
+		return gitStatusMsg(st)
+	}
+}
+
+// parseGitStatus reads `git status --porcelain=v2 --branch` output.
+func parseGitStatus(lines []string) gitStatus {
+	st := gitStatus{Loaded: true}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "#":
+			switch fields[1] {
+			case "branch.head":
+				st.Branch = strings.Join(fields[2:], " ")
+			case "branch.upstream":
+				st.Upstream = strings.Join(fields[2:], " ")
+			case "branch.ab":
+				if len(fields) >= 4 {
+					st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+					st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+				}
+			}
+		case "1", "2", "u":
+			// 1 XY sub mH mI mW hH hI path
+			// 2 XY sub mH mI mW hH hI Xscore path<TAB>orig
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			// Split the fixed fields off so the path keeps its spacing
+			n := map[string]int{"1": 8, "2": 9, "u": 10}[fields[0]]
+			parts := strings.SplitN(line, " ", n+1)
+			if len(parts) <= n {
+				continue
+			}
+			path := parts[n]
+			if i := strings.IndexByte(path, '\t'); i >= 0 {
+				path = path[:i]
+			}
+			xy := fields[1]
+			if fields[0] == "u" {
+				st.Unstaged = append(st.Unstaged, path+" (conflict)")
+				continue
+			}
+			if xy[0] != '.' {
+				st.Staged = append(st.Staged, path)
+			}
+			if len(xy) > 1 && xy[1] != '.' {
+				st.Unstaged = append(st.Unstaged, path)
+			}
+		case "?":
+			st.Untracked = append(st.Untracked, line[2:])
+		}
+	}
+	return st
+}
+
+// parseBranches reads full ref names. Slashes are common in local names
+// (feature/x), so only the ref prefix tells local and remote apart.
+func parseBranches(refs []string) []gitBranch {
+	var out []gitBranch
+	for _, ref := range refs {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			out = append(out, gitBranch{Name: strings.TrimPrefix(ref, "refs/heads/")})
+		case strings.HasPrefix(ref, "refs/remotes/") && !strings.HasSuffix(ref, "/HEAD"):
+			out = append(out, gitBranch{Name: strings.TrimPrefix(ref, "refs/remotes/"), Remote: true})
+		}
+	}
+	return out
+}
+
+// localBranches returns the local branches, excluding the current one.
+func (st gitStatus) localBranches() []gitBranch {
+	var out []gitBranch
+	for _, b := range st.Branches {
+		if b.Name != st.Branch && !b.Remote {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// inGitCategory reports whether the current category is Git or below it.
+func (m *Model) inGitCategory() bool {
+	for _, id := range []string{"git", hereID + "-git"} {
+		if gi := categoryIndex(m.categories, id); gi >= 0 && m.isDescendant(m.catIndex, gi) {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshGitCmd reloads the status when in a repository.
+func (m *Model) refreshGitCmd() tea.Cmd {
+	if m.here.GitRoot == "" {
+		return nil
+	}
+	return loadGitStatusCmd(m.here.GitRoot)
+}
+
+// gitTickCmd starts the periodic refresh when in a repository.
+func (m *Model) gitTickCmd() tea.Cmd {
+	if m.here.GitRoot == "" {
+		return nil
+	}
+	return gitRefreshTick()
+}
+
+// gitStatusLines renders the repository section of the detail pane.
+func (m *Model) gitStatusLines(width int) []string {
+	st := m.git
+	if m.here.GitRoot == "" || !st.Loaded {
+		return nil
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Bold(true)
+	branchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+	lines := []string{labelStyle.Render("  ┌─── REPOSITORY ───┐")}
+
+	if st.Err != nil {
+		return append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(colors.error)).
+			Render(fmt.Sprintf("  git status failed: %v", st.Err)))
+	}
+
+	head := "  🌿 " + branchStyle.Render(st.Branch)
+	if st.Upstream != "" {
+		head += mutedStyle.Render(" → " + st.Upstream)
+		if st.Ahead > 0 {
+			head += lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success)).Render(fmt.Sprintf(" ↑%d", st.Ahead))
+		}
+		if st.Behind > 0 {
+			head += lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning)).Render(fmt.Sprintf(" ↓%d", st.Behind))
+		}
+	}
+	lines = append(lines, head)
+
+	group := func(icon, title, color string, files []string) {
+		if len(files) == 0 {
+			return
+		}
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+		lines = append(lines, style.Bold(true).Render(fmt.Sprintf("  %s %s (%d)", icon, title, len(files))))
+		for i, f := range files {
+			if i == gitMaxFiles {
+				lines = append(lines, mutedStyle.Render(fmt.Sprintf("      … %d more", len(files)-i)))
+				break
+			}
+			if lipgloss.Width(f) > width-10 {
+				f = "…" + string([]rune(f)[len([]rune(f))-max(1, width-11):])
+			}
+			lines = append(lines, style.Render("      "+f))
+		}
+	}
+	group("●", "Staged", colors.success, st.Staged)
+	group("✚", "Unstaged", colors.warning, st.Unstaged)
+	group("?", "Untracked", colors.textDim, st.Untracked)
+	group("📦", "Stashes", colors.secondary, st.Stashes)
+
+	if len(st.Staged)+len(st.Unstaged)+len(st.Untracked) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success)).Render("  ✓ Working tree clean"))
+	}
+	return lines
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         BRANCH PICKER
+// ══════════════════════════════════════════════════════════════════
+
+// branchPicker describes the picker of a command taking a branch.
+type branchPicker struct {
+	Line   string // built from the branch with %s
+	Delete string // line for D, always confirmed; "" when not offered
+	Local  bool   // only local branches
+}
+
+// branchPickers maps commands taking a branch to their picker.
+var branchPickers = map[string]branchPicker{
+	"gco": {Line: "gco %s"},
+	"gb":  {Line: "gco %s", Delete: "gb -d %s", Local: true},
+}
+
+// openBranchPicker shows the branch list for c, if c takes a branch and
+// branches are known. It reports whether the picker opened.
+func (m *Model) openBranchPicker(c Command) bool {
+	if _, ok := branchPickers[c.Cmd]; !ok || len(m.git.Branches) == 0 {
+		return false
+	}
+	m.picker = c
+	m.setScreen(screenBranches)
+	return true
+}
+
+// pickerBranches lists the branches offered by the picker: every branch
+// but the current one, or only local ones for pickers marked Local.
+func (m *Model) pickerBranches() []gitBranch {
+	if branchPickers[m.picker.Cmd].Local {
+		return m.git.localBranches()
+	}
+	var out []gitBranch
+	for _, b := range m.git.Branches {
+		if b.Remote || b.Name != m.git.Branch {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// pickedLine builds the line for the selected branch from format. The
+// name is quoted: ref names may contain ;, $, ( and other characters
+// PowerShell would run.
+func (m *Model) pickedLine(format string) (string, bool) {
+	branches := m.pickerBranches()
+	if format == "" || m.panelIndex >= len(branches) {
+		return "", false
+	}
+	return fmt.Sprintf(format, psQuote(branches[m.panelIndex].Name)), true
+}
+
+func (m Model) handleBranchesKey(key string) (tea.Model, tea.Cmd) {
+	n := len(m.pickerBranches())
+	switch key {
+	case "up", "k":
+		m.movePanel(-1, n)
+	case "down", "j":
+		m.movePanel(1, n)
+	case "pgup":
+		m.movePanel(-10, n)
+	case "pgdown":
+		m.movePanel(10, n)
+	case "enter", "y":
+		if line, ok := m.pickedLine(branchPickers[m.picker.Cmd].Line); ok {
+			m.copyText(line, m.picker.Cmd)
+			m.setScreen(screenCatalog)
+		}
+	case "r":
+		if line, ok := m.pickedLine(branchPickers[m.picker.Cmd].Line); ok {
+			m.setScreen(screenCatalog)
+			return m, runLine(line)
+		}
+	case "D":
+		if line, ok := m.pickedLine(branchPickers[m.picker.Cmd].Delete); ok {
+			m.askConfirmCmd(fmt.Sprintf("Run %s?", line), func(m *Model) tea.Cmd {
+				m.setScreen(screenCatalog)
+				return runLine(line)
+			})
+		}
+	case "c":
+		m.setScreen(screenCatalog)
+		m.copyText(m.picker.Cmd, m.picker.Cmd)
+	case "esc":
+		m.setScreen(screenCatalog)
+	}
+	return m, nil
+}
+
+func (m *Model) viewBranches() string {
+	branches := m.pickerBranches()
+	localStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+	remoteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+
+	rows := make([]string, 0, len(branches))
+	picker := branchPickers[m.picker.Cmd]
+	for _, b := range branches {
+		style, icon := localStyle, "🌿"
+		if b.Remote {
+			style, icon = remoteStyle, "☁️"
+		}
+		rows = append(rows, fmt.Sprintf(" %s %s  %s", icon, style.Render(b.Name),
+			lineStyle.Render("$ "+fmt.Sprintf(picker.Line, b.Name))))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.textMuted)).
+			Italic(true).
+			Render("  No other branches"))
+	}
+
+	footer := fmt.Sprintf("on %s • enter copy • r run • c copy bare %s • esc back", m.git.Branch, m.picker.Cmd)
+	if picker.Delete != "" {
+		footer = fmt.Sprintf("on %s • enter copy • r run • D delete • c copy bare %s • esc back", m.git.Branch, m.picker.Cmd)
+	}
+	return m.renderPanel("🌿 Pick a branch for "+m.picker.Cmd, "emerald", rows, m.panelIndex, footer)
+}
@@ -0,0 +1,118 @@
+// gitstatus_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseGitStatus(t *testing.T) {
+	lines := []string{
+		"# branch.oid 1234567890abcdef",
+		"# branch.head feature/x",
+		"# branch.upstream origin/feature/x",
+		"# branch.ab +2 -1",
+		"1 M. N... 100644 100644 100644 abc def staged.go",
+		"1 .M N... 100644 100644 100644 abc def unstaged.go",
+		"1 MM N... 100644 100644 100644 abc def both.go",
+		"2 R. N... 100644 100644 100644 abc def R100 new name.go\told.go",
+		"u UU N... 100644 100644 100644 100644 a b c conflict.go",
+		"? untracked  file.txt",
+		"! ignored.log",
+	}
+	want := gitStatus{
+		Loaded:    true,
+		Branch:    "feature/x",
+		Upstream:  "origin/feature/x",
+		Ahead:     2,
+		Behind:    1,
+		Staged:    []string{"staged.go", "both.go", "new name.go"},
+		Unstaged:  []string{"unstaged.go", "both.go", "conflict.go (conflict)"},
+		Untracked: []string{"untracked  file.txt"},
+	}
+	if got := parseGitStatus(lines); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGitStatus() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseBranches(t *testing.T) {
+	refs := []string{
+		"refs/heads/main",
+		"refs/heads/feature/x",
+		"refs/remotes/origin/HEAD",
+		"refs/remotes/origin/main",
+		"refs/remotes/origin/feature/x",
+		"refs/tags/v1.0",
+	}
+	want := []gitBranch{
+		{Name: "main"},
+		{Name: "feature/x"},
+		{Name: "origin/main", Remote: true},
+		{Name: "origin/feature/x", Remote: true},
+	}
+	if got := parseBranches(refs); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBranches() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLocalBranches(t *testing.T) {
+	st := gitStatus{
+		Branch: "main",
+		Branches: []gitBranch{
+			{Name: "main"},
+			{Name: "feature/x"},
+			{Name: "fix"},
+			{Name: "origin/main", Remote: true},
+		},
+	}
+	want := []gitBranch{{Name: "feature/x"}, {Name: "fix"}}
+	if got := st.localBranches(); !reflect.DeepEqual(got, want) {
+		t.Errorf("localBranches() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBranchPickerDeleteAsks(t *testing.T) {
+	m := Model{config: defaultConfig()}
+	m.git = gitStatus{Branch: "main", Branches: []gitBranch{{Name: "main"}, {Name: "old"}}}
+	if !m.openBranchPicker(Command{Cmd: "gb"}) {
+		t.Fatal("picker did not open for gb")
+	}
+	if line, _ := m.pickedLine(branchPickers["gb"].Line); line != "gco 'old'" {
+		t.Errorf("gb picks %q, want a checkout", line)
+	}
+
+	mm, cmd := m.handleBranchesKey("D")
+	m = mm.(Model)
+	if cmd != nil || m.confirm == nil {
+		t.Fatal("D ran without asking")
+	}
+	mm, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m = mm.(Model); m.confirm != nil || m.screen != screenBranches {
+		t.Error("declining did not cancel")
+	}
+}
+
+func TestPickedLineQuotesBranch(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"feature/x", "gco 'feature/x'"},
+		{"x;calc", "gco 'x;calc'"},
+		{"a$(Remove-Item -Recurse ~)", "gco 'a$(Remove-Item -Recurse ~)'"},
+		{"it's`(x)", "gco 'it''s`(x)'"},
+		{"a\u2019;calc", "gco 'a\u2019\u2019;calc'"},
+	}
+	for _, tt := range tests {
+		m := Model{config: defaultConfig()}
+		m.git = gitStatus{Branch: "main", Branches: []gitBranch{{Name: "main"}, {Name: tt.name, Remote: true}}}
+		if !m.openBranchPicker(Command{Cmd: "gco"}) {
+			t.Fatal("picker did not open for gco")
+		}
+		if line, ok := m.pickedLine(branchPickers["gco"].Line); !ok || line != tt.want {
+			t.Errorf("pickedLine() for %q = %q, want %q", tt.name, line, tt.want)
+		}
+	}
+}
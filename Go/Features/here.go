@@ -0,0 +1,204 @@
+// here.go
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         HERE (WORKING DIRECTORY CONTEXT)
+// ══════════════════════════════════════════════════════════════════
+
+// hereID is the ID of the virtual "Here" category.
+const hereID = "here"
+
+// hereContext is what was detected in the working directory.
+type hereContext struct {
+	Dir     string
+	GitRoot string // "" outside a repository
+	Make    []Command
+	Scripts []Command
+	Go      []Command
+}
+
+// detectHere inspects dir for a git repository, Makefile, package.json
+// and go.mod.
+func detectHere(dir string) hereContext {
+	h := hereContext{Dir: dir, GitRoot: findGitRoot(dir)}
+	h.Make = makeTargets(filepath.Join(dir, "Makefile"))
+	h.Scripts = packageScripts(dir)
+	h.Go = goTasks(filepath.Join(dir, "go.mod"))
+	return h
+}
+
+// findGitRoot walks up from dir to the directory holding .git.
+func findGitRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+var makeTargetRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.\-/]*)\s*:([^=]|$)`)
+
+// makeTargets lists the explicit targets of a Makefile. A trailing
+// "## text" comment on the rule line, or a "#" comment right above it,
+// becomes the description.
+func makeTargets(path string) []Command {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var out []Command
+	for i, line := range lines {
+		m := makeTargetRe.FindStringSubmatch(line)
+		if m == nil || seen[m[1]] || strings.ContainsAny(m[1], "%$") {
+			continue
+		}
+		seen[m[1]] = true
+
+		desc := ""
+		if j := strings.Index(line, "##"); j >= 0 {
+			desc = strings.TrimSpace(line[j+2:])
+		} else if i > 0 && strings.HasPrefix(strings.TrimSpace(lines[i-1]), "#") {
+			desc = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(lines[i-1]), "#"))
+		}
+		if desc == "" {
+			desc = "Makefile target " + m[1]
+		}
+		out = append(out, Command{
+			Cmd:     "make " + m[1],
+			Desc:    desc,
+			Example: "make " + m[1],
+			Usage:   fmt.Sprintf("Defined in Makefile:%d", i+1),
+			Tags:    []string{"make", "build"},
+		})
+	}
+	return out
+}
+
+// packageScripts lists package.json scripts, run with the package manager
+// implied by the lock file.
+func packageScripts(dir string) []Command {
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := loadJSON(filepath.Join(dir, "package.json"), &pkg); err != nil || len(pkg.Scripts) == 0 {
+		return nil
+	}
+
+	runner := "npm run"
+	switch {
+	case fileExists(filepath.Join(dir, "pnpm-lock.yaml")):
+		runner = "pnpm run"
+	case fileExists(filepath.Join(dir, "yarn.lock")):
+		runner = "yarn run"
+	case fileExists(filepath.Join(dir, "bun.lockb")):
+		runner = "bun run"
+	}
+
+	names := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]Command, 0, len(names))
+	for _, name := range names {
+		out = append(out, Command{
+			Cmd:     runner + " " + name,
+			Desc:    pkg.Scripts[name],
+			Example: runner + " " + name,
+			Usage:   "package.json script",
+			Tags:    []string{"npm", "script"},
+		})
+	}
+	return out
+}
+
+// goTasks offers the usual go tool commands when go.mod exists.
+func goTasks(path string) []Command {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	module := ""
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "module "); ok {
+			module = strings.TrimSpace(rest)
+			break
+		}
+	}
+
+	tasks := []struct{ cmd, desc string }{
+		{"go test ./...", "Run all tests"},
+		{"go build ./...", "Build all packages"},
+		{"go vet ./...", "Report suspicious constructs"},
+		{"go run .", "Run the main package"},
+		{"go mod tidy", "Sync go.mod with the imports"},
+	}
+	out := make([]Command, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, Command{
+			Cmd:     t.cmd,
+			Desc:    t.desc,
+			Example: t.cmd,
+			Usage:   "Module " + module,
+			Tags:    []string{"go", "build"},
+		})
+	}
+	return out
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// empty reports whether nothing context-relevant was found.
+func (h hereContext) empty() bool {
+	return h.GitRoot == "" && len(h.Make) == 0 && len(h.Scripts) == 0 && len(h.Go) == 0
+}
+
+// hereCategories builds the virtual Here category and its subcategories.
+// gitCmds are the catalog's git commands, offered when in a repository.
+func (h hereContext) hereCategories(gitCmds []Command) []Category {
+	if h.empty() {
+		return nil
+	}
+	cats := []Category{{
+		ID: hereID, Name: "Here", Icon: "📍", Gradient: "aurora", Virtual: true,
+	}}
+	add := func(id, name, icon string, cmds []Command) {
+		if len(cmds) == 0 {
+			return
+		}
+		cats = append(cats, Category{
+			ID: hereID + "-" + id, Name: name, Icon: icon, Gradient: "aurora",
+			Parent: hereID, Virtual: true, Commands: cmds,
+		})
+	}
+	if h.GitRoot != "" {
+		add("git", "Git", "🌱", gitCmds)
+	}
+	add("make", "Make", "🔨", h.Make)
+	add("npm", "Scripts", "📦", h.Scripts)
+	add("go", "Go", "🐹", h.Go)
+	return cats
+}
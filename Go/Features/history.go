@@ -0,0 +1,231 @@
+// history.go
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         SHELL HISTORY IMPORT
+// ══════════════════════════════════════════════════════════════════
+
+// minSuggestCount is how often a line must be typed to be suggested.
+const minSuggestCount = 3
+
+// historyStats summarises what the user actually types.
+type historyStats struct {
+	Names   map[string]int // first word of each command segment -> count
+	Lines   map[string]int // full trimmed line -> count
+	Sources []string       // files that were read
+}
+
+type historyLoadedMsg historyStats
+
+// historyFiles returns the history files to read. PSReadLine is always
+// included; bash and zsh are added when listed in FEATURES_SHELL_HISTORY
+// (e.g. "bash,zsh").
+func historyFiles() []string {
+	home, _ := os.UserHomeDir()
+
+	var files []string
+	if runtime.GOOS == "windows" {
+		files = append(files, filepath.Join(os.Getenv("APPDATA"),
+			"Microsoft", "Windows", "PowerShell", "PSReadLine", "ConsoleHost_history.txt"))
+	} else {
+		files = append(files, filepath.Join(home,
+			".local", "share", "powershell", "PSReadLine", "ConsoleHost_history.txt"))
+	}
+
+	for _, sh := range strings.Split(os.Getenv("FEATURES_SHELL_HISTORY"), ",") {
+		switch strings.TrimSpace(strings.ToLower(sh)) {
+		case "bash":
+			files = append(files, filepath.Join(home, ".bash_history"))
+		case "zsh":
+			if h := os.Getenv("HISTFILE"); h != "" && strings.HasSuffix(h, "zsh_history") {
+				files = append(files, h)
+			} else {
+				files = append(files, filepath.Join(home, ".zsh_history"))
+			}
+		}
+	}
+	return files
+}
+
+// loadHistoryCmd reads the history files in the background.
+func loadHistoryCmd() tea.Msg {
+	return historyLoadedMsg(readHistory(historyFiles()))
+}
+
+func readHistory(files []string) historyStats {
+	st := historyStats{
+		Names: make(map[string]int),
+		Lines: make(map[string]int),
+	}
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		st.Sources = append(st.Sources, path)
+
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		var pending string
+		for sc.Scan() {
+			line := sc.Text()
+			// PSReadLine continues multi-line input with a trailing backtick
+			if strings.HasSuffix(line, "`") {
+				pending += strings.TrimSuffix(line, "`") + " "
+				continue
+			}
+			line = pending + line
+			pending = ""
+			st.add(parseHistoryLine(line))
+		}
+		f.Close()
+	}
+	return st
+}
+
+// parseHistoryLine strips the zsh extended-history prefix (": 123:0;cmd").
+func parseHistoryLine(line string) string {
+	if strings.HasPrefix(line, ": ") {
+		if i := strings.Index(line, ";"); i > 0 {
+			line = line[i+1:]
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+func (st *historyStats) add(line string) {
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	st.Lines[line]++
+	for _, seg := range splitSegments(line) {
+		if name := commandName(seg); name != "" {
+			st.Names[strings.ToLower(name)]++
+		}
+	}
+}
+
+// splitSegments splits a command line on ;, |, && and ||.
+func splitSegments(line string) []string {
+	f := func(r rune) bool { return r == ';' || r == '|' || r == '&' }
+	return strings.FieldsFunc(line, f)
+}
+
+// commandName returns the first word of a command segment.
+func commandName(seg string) string {
+	fields := strings.Fields(seg)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], "()&.")
+}
+
+// typedCount returns how often the catalog command c appears in history.
+func (st historyStats) typedCount(c Command) int {
+	if st.Names == nil {
+		return 0
+	}
+	return st.Names[strings.ToLower(commandName(c.Cmd))]
+}
+
+// historySuggestion is a frequently typed line that is not in the catalog.
+type historySuggestion struct {
+	Line  string
+	Count int
+}
+
+// suggestions lists lines typed at least minSuggestCount times whose
+// command is not in the catalog, most frequent first.
+func (m *Model) suggestions() []historySuggestion {
+	known := make(map[string]bool)
+	for _, cat := range m.categories {
+		for _, c := range cat.Commands {
+			known[strings.ToLower(commandName(c.Cmd))] = true
+		}
+	}
+
+	var out []historySuggestion
+	for line, n := range m.history.Lines {
+		if n < minSuggestCount || known[strings.ToLower(commandName(line))] {
+			continue
+		}
+		out = append(out, historySuggestion{Line: line, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         SUGGESTIONS SCREEN
+// ══════════════════════════════════════════════════════════════════
+
+func (m Model) handleSuggestKey(key string) (tea.Model, tea.Cmd) {
+	sugg := m.suggestions()
+	switch key {
+	case "up", "k":
+		m.movePanel(-1, len(sugg))
+	case "down", "j":
+		m.movePanel(1, len(sugg))
+	case "pgup":
+		m.movePanel(-10, len(sugg))
+	case "pgdown":
+		m.movePanel(10, len(sugg))
+	case "a", "enter":
+		if m.panelIndex < len(sugg) {
+			m.openCommandForm(false)
+			m.form.Title = "Add from history"
+			m.form.Fields[1].Input.SetValue(sugg[m.panelIndex].Line)
+			m.form.focusField(2)
+			return m, textinput.Blink
+		}
+	case "esc", "S":
+		m.setScreen(screenCatalog)
+	}
+	return m, nil
+}
+
+func (m *Model) viewSuggestions() string {
+	sugg := m.suggestions()
+
+	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning)).Bold(true)
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+
+	rows := make([]string, 0, len(sugg))
+	for _, s := range sugg {
+		rows = append(rows, fmt.Sprintf(" %s  %s",
+			countStyle.Render(fmt.Sprintf("%5d×", s.Count)),
+			lineStyle.Render(s.Line)))
+	}
+	if len(rows) == 0 {
+		msg := "  No frequent uncatalogued commands found"
+		if len(m.history.Sources) == 0 {
+			msg = "  No shell history found"
+		}
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.textMuted)).
+			Italic(true).
+			Render(msg))
+	}
+
+	footer := fmt.Sprintf("a: add to catalog • esc: back • %d history files", len(m.history.Sources))
+	return m.renderPanel("💡 Suggestions from history", "gold", rows, m.panelIndex, footer)
+}
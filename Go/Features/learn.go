@@ -0,0 +1,328 @@
+// learn.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         LEARN MODE
+// ══════════════════════════════════════════════════════════════════
+
+const learnFile = "learn.json"
+
+// leitnerIntervals is the review delay for each box; a correct answer
+// moves a card up one box, a wrong one sends it back to box 0.
+var leitnerIntervals = []time.Duration{
+	0,
+	24 * time.Hour,
+	2 * 24 * time.Hour,
+	4 * 24 * time.Hour,
+	8 * 24 * time.Hour,
+	16 * 24 * time.Hour,
+	32 * 24 * time.Hour,
+}
+
+// learnedBox is the box from which a card counts as learned.
+const learnedBox = 3
+
+// Card is the review record of one command.
+type Card struct {
+	Box     int       `json:"box"`
+	Due     time.Time `json:"due"`
+	Correct int       `json:"correct"`
+	Wrong   int       `json:"wrong"`
+}
+
+type learnStore struct {
+	Cards map[string]Card `json:"cards"`
+}
+
+func loadLearn() (map[string]Card, error) {
+	ls := learnStore{Cards: make(map[string]Card)}
+	err := loadJSON(statePath(learnFile), &ls)
+	if ls.Cards == nil {
+		ls.Cards = make(map[string]Card)
+	}
+	return ls.Cards, err
+}
+
+func saveLearn(cards map[string]Card) error {
+	return saveJSON(statePath(learnFile), learnStore{Cards: cards})
+}
+
+func cardKey(catID, cmd string) string {
+	return catID + "/" + cmd
+}
+
+// grade updates a card after an answer.
+func (c Card) grade(correct bool, now time.Time) Card {
+	if correct {
+		c.Correct++
+		c.Box = min(c.Box+1, len(leitnerIntervals)-1)
+	} else {
+		c.Wrong++
+		c.Box = 0
+	}
+	c.Due = now.Add(leitnerIntervals[c.Box])
+	return c
+}
+
+// Quiz modes
+const (
+	quizDescToCmd = iota
+	quizCmdToUsage
+)
+
+// quizState is the card currently being asked.
+type quizState struct {
+	CatID    string
+	Cmd      Command
+	Mode     int
+	Input    textinput.Model
+	Revealed bool
+	Answered bool
+	Correct  bool
+	Done     bool // nothing left to review
+}
+
+// nextCard picks the most overdue card, preferring the current category,
+// then unseen commands. ok is false when nothing is due.
+func (m *Model) nextCard() (catID string, cmd Command, ok bool) {
+	now := time.Now()
+	type candidate struct {
+		catID string
+		cmd   Command
+		due   time.Time
+		prio  int
+	}
+	var cands []candidate
+	for ci, cat := range m.categories {
+		if cat.Virtual {
+			continue
+		}
+		for _, c := range cat.Commands {
+			card, seen := m.cards[cardKey(cat.ID, c.Cmd)]
+			if seen && card.Due.After(now) {
+				continue
+			}
+			prio := 2
+			if seen {
+				prio = 0
+			}
+			if ci == m.catIndex {
+				prio--
+			}
+			cands = append(cands, candidate{cat.ID, c, card.Due, prio})
+		}
+	}
+	if len(cands) == 0 {
+		return "", Command{}, false
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		if cands[i].prio != cands[j].prio {
+			return cands[i].prio < cands[j].prio
+		}
+		return cands[i].due.Before(cands[j].due)
+	})
+	// Avoid asking the same card twice in a row
+	pick := cands[0]
+	if m.quiz != nil && len(cands) > 1 && pick.cmd.Cmd == m.quiz.Cmd.Cmd {
+		pick = cands[1]
+	}
+	return pick.catID, pick.cmd, true
+}
+
+// startQuiz opens learn mode on the next due card.
+func (m *Model) startQuiz() tea.Cmd {
+	m.screen = screenLearn
+	catID, cmd, ok := m.nextCard()
+	if !ok {
+		m.quiz = &quizState{Done: true}
+		return nil
+	}
+
+	mode := quizDescToCmd
+	if cmd.UsageText(m.locale) != "" && (m.frame+len(cmd.Cmd))%2 == 1 {
+		mode = quizCmdToUsage
+	}
+
+	ti := textinput.New()
+	ti.Placeholder = "type the command…"
+	ti.CharLimit = 64
+	ti.Width = 30
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary))
+	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent))
+	ti.Focus()
+
+	m.quiz = &quizState{CatID: catID, Cmd: cmd, Mode: mode, Input: ti}
+	return textinput.Blink
+}
+
+// answerQuiz grades the current card and persists the schedule.
+func (m *Model) answerQuiz(correct bool) {
+	q := m.quiz
+	q.Answered, q.Correct = true, correct
+
+	key := cardKey(q.CatID, q.Cmd.Cmd)
+	cards := make(map[string]Card, len(m.cards)+1)
+	for k, v := range m.cards {
+		cards[k] = v
+	}
+	cards[key] = cards[key].grade(correct, time.Now())
+	m.cards = cards
+	if err := saveLearn(cards); err != nil {
+		m.showToast(fmt.Sprintf("Save failed: %v", err), "error")
+	}
+}
+
+func (m Model) handleLearnKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	q := m.quiz
+	switch key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.quiz = nil
+		m.setScreen(screenCatalog)
+		return m, nil
+	}
+	if q == nil || q.Done {
+		return m, nil
+	}
+
+	if q.Answered {
+		if key == "enter" || key == " " {
+			return m, m.startQuiz()
+		}
+		return m, nil
+	}
+
+	switch q.Mode {
+	case quizDescToCmd:
+		switch key {
+		case "enter":
+			answer := strings.ToLower(strings.TrimSpace(q.Input.Value()))
+			m.answerQuiz(answer != "" && (answer == strings.ToLower(q.Cmd.Cmd) ||
+				answer == strings.ToLower(commandName(q.Cmd.Cmd))))
+			return m, nil
+		case "tab":
+			return m, m.startQuiz()
+		}
+		var cmd tea.Cmd
+		q.Input, cmd = q.Input.Update(msg)
+		return m, cmd
+
+	case quizCmdToUsage:
+		switch {
+		case !q.Revealed && (key == "enter" || key == " "):
+			q.Revealed = true
+		case q.Revealed && (key == "y" || key == "Y"):
+			m.answerQuiz(true)
+		case q.Revealed && (key == "n" || key == "N"):
+			m.answerQuiz(false)
+		case key == "tab":
+			return m, m.startQuiz()
+		}
+	}
+	return m, nil
+}
+
+// categoryProgress returns learned and total cards for a category.
+func (m *Model) categoryProgress(cat Category) (learned, total int) {
+	for _, c := range cat.Commands {
+		if m.cards[cardKey(cat.ID, c.Cmd)].Box >= learnedBox {
+			learned++
+		}
+	}
+	return learned, len(cat.Commands)
+}
+
+func (m *Model) viewLearn() string {
+	q := m.quiz
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Bold(true)
+	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text)).Bold(true)
+	answerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+	goodStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success)).Bold(true)
+	badStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.error)).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Italic(true)
+
+	var rows []string
+	footer := "esc back"
+
+	switch {
+	case q == nil || q.Done:
+		rows = append(rows, "", goodStyle.Render("  🎉 All caught up — nothing is due for review"))
+
+	case q.Mode == quizDescToCmd:
+		rows = append(rows, "", labelStyle.Render("  WHICH COMMAND…"), "")
+		rows = append(rows, "  "+promptStyle.Render(q.Cmd.Description(m.locale)))
+		if len(q.Cmd.Tags) > 0 {
+			rows = append(rows, "  "+hintStyle.Render("#"+strings.Join(q.Cmd.Tags, " #")))
+		}
+		rows = append(rows, "", "  ❯ "+q.Input.View())
+		footer = "enter answer • tab skip • esc back"
+
+	case q.Mode == quizCmdToUsage:
+		icon := cmdIcons[q.Cmd.Cmd]
+		if icon == "" {
+			icon = "•"
+		}
+		rows = append(rows, "", labelStyle.Render("  HOW DO YOU USE…"), "")
+		rows = append(rows, fmt.Sprintf("  %s  %s", icon, answerStyle.Render(q.Cmd.Cmd)))
+		rows = append(rows, "")
+		if q.Revealed {
+			rows = append(rows, "  "+promptStyle.Render(q.Cmd.UsageText(m.locale)))
+			if q.Cmd.Example != "" {
+				rows = append(rows, "  "+hintStyle.Render("$ "+q.Cmd.Example))
+			}
+			footer = "y knew it • n didn't • tab skip • esc back"
+		} else {
+			rows = append(rows, "  "+hintStyle.Render("Think of the usage, then press Enter to reveal"))
+			footer = "enter reveal • tab skip • esc back"
+		}
+	}
+
+	if q != nil && q.Answered {
+		card := m.cards[cardKey(q.CatID, q.Cmd.Cmd)]
+		rows = append(rows, "")
+		if q.Correct {
+			rows = append(rows, "  "+goodStyle.Render("✓ Correct!"))
+		} else {
+			rows = append(rows, "  "+badStyle.Render("✗ It was: ")+answerStyle.Render(q.Cmd.Cmd))
+		}
+		rows = append(rows, "  "+hintStyle.Render(fmt.Sprintf("Box %d • next review %s",
+			card.Box, card.Due.Format("Jan 2 15:04"))))
+		footer = "enter next • esc back"
+	}
+
+	// Progress per category
+	rows = append(rows, "", labelStyle.Render("  PROGRESS"))
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim)).Width(14)
+	for _, cat := range m.categories {
+		if cat.Virtual {
+			continue
+		}
+		learned, total := m.categoryProgress(cat)
+		grad := getGradient(m.gradientOf(cat))
+		barW := 20
+		filled := 0
+		if total > 0 {
+			filled = learned * barW / total
+		}
+		bar := lipgloss.NewStyle().Foreground(lipgloss.Color(grad[0])).Render(strings.Repeat("▰", filled)) +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.border)).Render(strings.Repeat("▱", barW-filled))
+		rows = append(rows, fmt.Sprintf("  %s %s %s %s", cat.Icon, nameStyle.Render(cat.Name), bar,
+			hintStyle.Render(fmt.Sprintf("%d/%d", learned, total))))
+	}
+
+	return m.renderPanel("🎓 Learn", "aurora", rows, -1, footer)
+}
@@ -0,0 +1,62 @@
+// learn_test.go
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCardGrade(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	last := len(leitnerIntervals) - 1
+	tests := []struct {
+		name    string
+		card    Card
+		correct bool
+		want    Card
+	}{
+		{"new card right", Card{}, true,
+			Card{Box: 1, Due: now.Add(24 * time.Hour), Correct: 1}},
+		{"new card wrong", Card{}, false,
+			Card{Box: 0, Due: now, Wrong: 1}},
+		{"moves up a box", Card{Box: 2, Correct: 2}, true,
+			Card{Box: 3, Due: now.Add(4 * 24 * time.Hour), Correct: 3}},
+		{"wrong resets", Card{Box: 4, Correct: 4, Wrong: 1}, false,
+			Card{Box: 0, Due: now, Correct: 4, Wrong: 2}},
+		{"stays in the last box", Card{Box: last}, true,
+			Card{Box: last, Due: now.Add(leitnerIntervals[last]), Correct: 1}},
+	}
+	for _, tt := range tests {
+		if got := tt.card.grade(tt.correct, now); got != tt.want {
+			t.Errorf("%s: grade() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNextCard(t *testing.T) {
+	now := time.Now()
+	m := Model{
+		categories: []Category{
+			{ID: "a", Commands: []Command{{Cmd: "a1"}, {Cmd: "a2"}}},
+			{ID: "b", Commands: []Command{{Cmd: "b1"}}},
+			{ID: "v", Virtual: true, Commands: []Command{{Cmd: "v1"}}},
+		},
+		cards: map[string]Card{
+			"a/a1": {Box: 2, Due: now.Add(time.Hour)},
+			"b/b1": {Box: 1, Due: now.Add(-time.Hour)},
+		},
+		catIndex: 0,
+	}
+	// b1 is overdue and beats the unseen a2, even outside the category
+	if cat, c, ok := m.nextCard(); !ok || cat != "b" || c.Cmd != "b1" {
+		t.Errorf("nextCard() = %s/%s, %v, want b/b1", cat, c.Cmd, ok)
+	}
+	m.cards["b/b1"] = Card{Box: 1, Due: now.Add(time.Hour)}
+	if cat, c, ok := m.nextCard(); !ok || cat != "a" || c.Cmd != "a2" {
+		t.Errorf("nextCard() = %s/%s, %v, want a/a2", cat, c.Cmd, ok)
+	}
+	m.cards["a/a2"] = Card{Box: 1, Due: now.Add(time.Hour)}
+	if _, c, ok := m.nextCard(); ok {
+		t.Errorf("nextCard() = %s, want nothing due", c.Cmd)
+	}
+}
@@ -0,0 +1,274 @@
+// locale.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         LOCALIZATION
+// ══════════════════════════════════════════════════════════════════
+
+// defaultLocale is the language the built-in catalog text is written in.
+const defaultLocale = "en"
+
+// LocalizedText holds the translatable fields of a Command for one locale.
+// Empty fields fall back to the command's default (English) text.
+type LocalizedText struct {
+	Desc  string
+	Usage string
+}
+
+// locales lists the selectable languages in toggle order.
+var locales = []struct {
+	Code string
+	Name string
+}{
+	{"en", "English"},
+	{"vi", "Tiếng Việt"},
+}
+
+// translations maps locale -> command -> localized text. They are merged
+// into Command.I18n by applyTranslations so the catalog itself stays readable.
+var translations = map[string]map[string]LocalizedText{
+	"vi": {
+		// Navigation
+		"des":  {Desc: "Chuyển đến thư mục Desktop", Usage: "Nhảy nhanh tới thư mục Desktop"},
+		"dl":   {Desc: "Chuyển đến thư mục Downloads", Usage: "Mở Downloads và liệt kê nội dung"},
+		"docs": {Desc: "Chuyển đến thư mục Documents", Usage: "Nhảy tới thư mục Documents"},
+		"cdd":  {Desc: "CD thông minh với lịch sử và tìm mờ", Usage: "cdd <tên-một-phần> - Dùng tìm kiếm mờ"},
+		"bm":   {Desc: "Trình quản lý dấu trang tương tác"},
+		"j":    {Desc: "Nhảy tới dấu trang đã lưu"},
+		"..":   {Desc: "Lên một cấp thư mục", Usage: "Chuyển tới thư mục cha"},
+		"...":  {Desc: "Lên hai cấp thư mục", Usage: "Lên 2 cấp"},
+		"-":    {Desc: "Quay lại thư mục trước", Usage: "Chuyển qua lại giữa thư mục hiện tại và trước đó"},
+		"home": {Desc: "Chuyển đến thư mục home", Usage: "Tới $HOME (~)"},
+		"root": {Desc: "Chuyển đến gốc ổ đĩa", Usage: "Tới C:\\ hoặc /"},
+		// Files
+		"mkfile":   {Desc: "Tạo file và tự tạo thư mục cha", Usage: "mkfile <đường-dẫn> - Tạo thư mục cha nếu cần"},
+		"touch":    {Desc: "Tạo file rỗng hoặc cập nhật thời gian"},
+		"nano":     {Desc: "Mở file trong trình soạn thảo thông minh", Usage: "nano <file> - Tự chọn trình soạn thảo phù hợp"},
+		"fastcopy": {Desc: "Sao chép file đa luồng"},
+		"extract":  {Desc: "Giải nén mọi định dạng lưu trữ", Usage: "extract <file> [đích] - Hỗ trợ zip/7z/tar/gz"},
+		"compress": {Desc: "Nén file thành ZIP"},
+		"trash":    {Desc: "Chuyển file vào Thùng rác", Usage: "trash <files...> - Xoá an toàn"},
+		"open":     {Desc: "Mở file hoặc thư mục trong Explorer", Usage: "open [đường-dẫn] - Mở bằng ứng dụng mặc định"},
+		"tree2":    {Desc: "Xem cây thư mục nâng cao"},
+		// System
+		"sysinfo":   {Desc: "Hiển thị đầy đủ thông tin hệ thống", Usage: "Hiện CPU, RAM, ổ đĩa, hệ điều hành"},
+		"top":       {Desc: "Trình quản lý tiến trình tương tác", Usage: "Theo dõi tiến trình theo thời gian thực"},
+		"ports":     {Desc: "Liệt kê các cổng mạng đang lắng nghe", Usage: "Hiện mọi cổng đang mở kèm PID"},
+		"killport":  {Desc: "Dừng tiến trình đang dùng một cổng", Usage: "killport <số-cổng>"},
+		"myip":      {Desc: "Hiện địa chỉ IP công khai và nội bộ", Usage: "Hiển thị mọi giao diện mạng"},
+		"speedtest": {Desc: "Kiểm tra tốc độ Internet", Usage: "Đo tốc độ tải xuống/tải lên"},
+		"battery":   {Desc: "Hiển thị trạng thái và độ chai pin", Usage: "Hiện mức sạc, độ chai, số chu kỳ"},
+		"cleantemp": {Desc: "Dọn file tạm và bộ nhớ đệm", Usage: "Xoá file tạm một cách an toàn"},
+		"up":        {Desc: "Kiểm tra website có hoạt động không", Usage: "up <tên-miền> - Kiểm tra HTTP"},
+		// Dev
+		"install": {Desc: "Cài đặt gói qua Winget", Usage: "install <tên-gói>"},
+		"calc":    {Desc: "Máy tính nhanh", Usage: "calc <biểu-thức>"},
+		"json":    {Desc: "Định dạng và kiểm tra JSON"},
+		"passgen": {Desc: "Tạo mật khẩu ngẫu nhiên an toàn"},
+		"timer":   {Desc: "Đồng hồ đếm ngược có thông báo", Usage: "timer <thời-lượng> - vd: 1h30m, 45s"},
+		"todo":    {Desc: "Quản lý công việc đơn giản"},
+		"short":   {Desc: "Rút gọn URL bằng is.gd"},
+		"cheat":   {Desc: "Hiển thị bảng tra cứu lệnh", Usage: "cheat <chủ-đề>"},
+		"web":     {Desc: "Tìm kiếm web nhanh từ terminal", Usage: "web <từ-khoá>"},
+		// Admin
+		"sudo":    {Desc: "Chạy lệnh với quyền quản trị", Usage: "sudo <lệnh>"},
+		"god":     {Desc: "Vào chế độ God Mode cấp SYSTEM", Usage: "Nâng quyền lên NT AUTHORITY\\SYSTEM"},
+		"ti":      {Desc: "Lấy quyền TrustedInstaller", Usage: "Quyền Windows cao nhất"},
+		"drop":    {Desc: "Hạ về quyền người dùng thường", Usage: "Trở lại ngữ cảnh người dùng thường"},
+		"def":     {Desc: "Bật/tắt Windows Defender"},
+		"avkill":  {Desc: "Dừng các tiến trình diệt virus", Usage: "Buộc tắt phần mềm diệt virus"},
+		"nuke":    {Desc: "Buộc dừng bất kỳ tiến trình nào", Usage: "nuke <tên-tiến-trình|pid>"},
+		"ghost":   {Desc: "Xoá toàn bộ log và dấu vết hệ thống", Usage: "Xoá event log, file tạm, lịch sử"},
+		"powerup": {Desc: "Bật mọi đặc quyền của token", Usage: "Bật SeDebugPrivilege, v.v."},
+		// Windows
+		"star":   {Desc: "Ghim cửa sổ để tránh bị đóng", Usage: "star <tiêu-đề-cửa-sổ>"},
+		"unstar": {Desc: "Bỏ ghim cửa sổ", Usage: "unstar <tiêu-đề-cửa-sổ>"},
+		"wm":     {Desc: "Trình quản lý cửa sổ dạng lát"},
+		"hyp":    {Desc: "Kiểm tra trạng thái Hypervisor", Usage: "Kiểm tra Hyper-V, VMware, VBox"},
+		"uefi":   {Desc: "Hiển thị thông tin UEFI/BIOS", Usage: "Hiện chi tiết firmware"},
+		"vmx":    {Desc: "Chèn lệnh vào máy ảo"},
+		"cmd":    {Desc: "Bảng lệnh nhanh", Usage: "Mở trình tìm lệnh mờ"},
+		// Search
+		"ff":       {Desc: "Tìm file theo tên với tìm mờ", Usage: "ff <mẫu> [-d thư-mục]"},
+		"ftext":    {Desc: "Tìm văn bản bên trong file", Usage: "ftext <văn-bản> [files]"},
+		"dup":      {Desc: "Tìm file trùng lặp", Usage: "dup [đường-dẫn] - Dùng SHA256"},
+		"recent":   {Desc: "Liệt kê file sửa đổi gần đây"},
+		"sizesort": {Desc: "Phân tích dung lượng thư mục", Usage: "sizesort [đường-dẫn] - Hiện mục lớn nhất"},
+		"count":    {Desc: "Đếm file, thư mục và số dòng", Usage: "count [mẫu] - Thống kê file"},
+		"hh":       {Desc: "Tìm trong lịch sử lệnh", Usage: "hh [từ-khoá] - Tìm mờ trong lịch sử"},
+		// Git
+		"gs":   {Desc: "Xem git status dạng nâng cao", Usage: "Git status nâng cao"},
+		"ga":   {Desc: "Đưa file vào vùng stage", Usage: "ga [files] - git add"},
+		"gc":   {Desc: "Commit các thay đổi đã stage", Usage: "gc '<thông-điệp>'"},
+		"gp":   {Desc: "Đẩy commit lên remote"},
+		"gl":   {Desc: "Kéo thay đổi từ remote"},
+		"glog": {Desc: "Xem đồ thị git log đẹp mắt"},
+		"gd":   {Desc: "Xem khác biệt giữa các file"},
+		"gb":   {Desc: "Liệt kê và quản lý nhánh"},
+		"gco":  {Desc: "Chuyển nhánh", Usage: "gco <nhánh> [-b mới]"},
+		"gst":  {Desc: "Cất tạm thay đổi hiện tại"},
+	},
+}
+
+// applyTranslations attaches the translation table to every command.
+func applyTranslations(cats []Category) {
+	for ci := range cats {
+		for i := range cats[ci].Commands {
+			cmd := &cats[ci].Commands[i]
+			for loc, table := range translations {
+				t, ok := table[cmd.Cmd]
+				if !ok {
+					continue
+				}
+				if cmd.I18n == nil {
+					cmd.I18n = make(map[string]LocalizedText)
+				}
+				cmd.I18n[loc] = t
+			}
+		}
+	}
+}
+
+// normalizeLocale turns values like "vi_VN.UTF-8" into "vi".
+func normalizeLocale(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, ".@"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexAny(s, "_-"); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// isKnownLocale reports whether code is one of the selectable locales.
+func isKnownLocale(code string) bool {
+	for _, l := range locales {
+		if l.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// localeFile remembers the language last chosen with L or :set lang.
+const localeFile = "locale.json"
+
+type localeState struct {
+	Locale string `json:"locale"`
+}
+
+// savedLocale returns the language last chosen in the UI, "" if none.
+func savedLocale() string {
+	var ls localeState
+	if loadJSON(statePath(localeFile), &ls) != nil {
+		return ""
+	}
+	return ls.Locale
+}
+
+// detectLocale picks the UI locale from FEATURES_LANG, then configured
+// (the config's locale), then the language last chosen in the UI, then
+// the usual POSIX variables, falling back to defaultLocale. An explicit
+// setting thus always beats the remembered toggle.
+func detectLocale(configured string) string {
+	candidates := []string{os.Getenv("FEATURES_LANG"), configured, savedLocale()}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		candidates = append(candidates, os.Getenv(env))
+	}
+	for _, c := range candidates {
+		if loc := normalizeLocale(c); isKnownLocale(loc) {
+			return loc
+		}
+	}
+	return defaultLocale
+}
+
+// setLocale switches the UI language and remembers it for the next start,
+// unless the config sets a locale, which wins at startup.
+func (m *Model) setLocale(code string) {
+	m.locale = code
+	if err := saveJSON(statePath(localeFile), localeState{Locale: code}); err != nil {
+		m.showToast(fmt.Sprintf("Language: %v", err), "error")
+		return
+	}
+	if cfg := m.config.Locale; cfg != "" && cfg != code {
+		m.showToast(fmt.Sprintf("Language: %s (config starts in %s)", localeName(code), localeName(cfg)), "info")
+		return
+	}
+	m.showToast(fmt.Sprintf("Language: %s", localeName(code)), "info")
+}
+
+// localeName returns the display name of a locale code.
+func localeName(code string) string {
+	for _, l := range locales {
+		if l.Code == code {
+			return l.Name
+		}
+	}
+	return code
+}
+
+// localeCodes lists the locale codes for messages, like "en, vi".
+func localeCodes() string {
+	codes := make([]string, len(locales))
+	for i, l := range locales {
+		codes[i] = l.Code
+	}
+	return strings.Join(codes, ", ")
+}
+
+// nextLocale returns the locale after code in toggle order.
+func nextLocale(code string) string {
+	for i, l := range locales {
+		if l.Code == code {
+			return locales[(i+1)%len(locales)].Code
+		}
+	}
+	return defaultLocale
+}
+
+// Description returns the command description in the given locale,
+// falling back to the default text.
+func (c Command) Description(locale string) string {
+	if t, ok := c.I18n[locale]; ok && t.Desc != "" {
+		return t.Desc
+	}
+	return c.Desc
+}
+
+// UsageText returns the usage line in the given locale, falling back to
+// the default text.
+func (c Command) UsageText(locale string) string {
+	if t, ok := c.I18n[locale]; ok && t.Usage != "" {
+		return t.Usage
+	}
+	return c.Usage
+}
+
+// matchesQuery reports whether a lower-cased query matches the command
+// name, tags, or description/usage text in any locale.
+func (c Command) matchesQuery(query string) bool {
+	if strings.Contains(strings.ToLower(c.Cmd), query) ||
+		strings.Contains(strings.ToLower(c.Desc), query) {
+		return true
+	}
+	for _, tag := range c.Tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return true
+		}
+	}
+	for _, t := range c.I18n {
+		if strings.Contains(strings.ToLower(t.Desc), query) ||
+			strings.Contains(strings.ToLower(t.Usage), query) {
+			return true
+		}
+	}
+	return false
+}
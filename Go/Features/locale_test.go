@@ -0,0 +1,68 @@
+// locale_test.go
+package main
+
+import "testing"
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"vi_VN.UTF-8", "vi"},
+		{"en-US", "en"},
+		{" EN ", "en"},
+		{"de_DE@euro", "de"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeLocale(tt.in); got != tt.want {
+			t.Errorf("normalizeLocale(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name       string
+		featLang   string
+		saved      string
+		configured string
+		lang       string
+		want       string
+	}{
+		{"default", "", "", "", "", defaultLocale},
+		{"LANG", "", "", "", "vi_VN.UTF-8", "vi"},
+		{"config over LANG", "", "", "en", "vi_VN.UTF-8", "en"},
+		{"config set, toggle saved", "", "vi", "en", "", "en"},
+		{"saved over LANG", "", "vi", "", "en_US.UTF-8", "vi"},
+		{"FEATURES_LANG over config", "en", "vi", "vi", "", "en"},
+		{"unknown values are skipped", "xx", "", "", "vi", "vi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FEATURES_HOME", t.TempDir())
+			t.Setenv("FEATURES_LANG", tt.featLang)
+			t.Setenv("LC_ALL", "")
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tt.lang)
+			if tt.saved != "" {
+				if err := saveJSON(statePath(localeFile), localeState{Locale: tt.saved}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := detectLocale(tt.configured); got != tt.want {
+				t.Errorf("detectLocale(%q) = %q, want %q", tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleToggleIsSaved(t *testing.T) {
+	t.Setenv("FEATURES_HOME", t.TempDir())
+	t.Setenv("FEATURES_LANG", "")
+	var m Model
+	m.config = defaultConfig()
+	m.setLocale("vi")
+	if got := savedLocale(); got != "vi" {
+		t.Fatalf("savedLocale() = %q, want vi", got)
+	}
+}
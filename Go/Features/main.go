@@ -2,6 +2,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"termshot"
 )
 
 // ══════════════════════════════════════════════════════════════════
@@ -155,21 +157,33 @@ var enhancedGradients = map[string][]string{
 // ══════════════════════════════════════════════════════════════════
 
 type Command struct {
-	Cmd     string
-	Desc    string
-	Hot     string
-	Tags    []string
-	Example string
-	Usage   string
-	Since   string
-	Danger  bool
+	Cmd       string
+	Desc      string
+	Hot       string
+	Tags      []string
+	Example   string
+	Usage     string
+	Since     string
+	Risk      string   // riskSafe.. riskSystem; "" is safe
+	Privilege string   // privUser.. privTrusted; "" is user
+	Platforms []string // "windows", "linux", "macos", "pwsh>=7.2"; empty means everywhere
+	I18n      map[string]LocalizedText
+	Source    string // "" for built-in, otherwise the overlay it came from
 }
 
+// Command sources
+const (
+	sourceUser = "user"
+	sourceTeam = "team"
+)
+
 type Category struct {
 	ID       string
 	Name     string
 	Icon     string
 	Gradient string
+	Parent   string // ID of the parent category, "" at the top level
+	Virtual  bool   // generated view such as Here, not part of the catalog
 	Commands []Command
 }
 
@@ -181,14 +195,42 @@ type HitBox struct {
 	Data       interface{}
 }
 
+// Split ratio bounds, in percent of the content given to the list
+const (
+	defaultSplitRatio = 45
+	minSplitRatio     = 20
+	maxSplitRatio     = 80
+)
+
+// minSidebarWidth is the narrowest terminal that still shows the sidebar
+const minSidebarWidth = 80
+
+// minStackedH is the least content height that fits list and detail stacked
+const minStackedH = 16
+
+// Content layout modes
+const (
+	layoutSplit    = "split"   // list left, detail right
+	layoutStacked  = "stacked" // list above detail
+	layoutListOnly = "list"    // too small for a detail pane
+	layoutDetail   = "detail"  // full-screen detail
+)
+
 type Layout struct {
+	Mode       string
 	HeaderH    int
 	TabsH      int
 	SearchH    int
 	ContentH   int
 	StatusH    int
+	TrayH      int
+	SideW      int // category sidebar, 0 when hidden
 	ListW      int
 	DetailW    int
+	ListH      int
+	DetailH    int
+	DetailX    int
+	DetailY    int
 	Padding    int
 	TabW       int
 	TabsPerRow int
@@ -206,6 +248,7 @@ type Model struct {
 	searchMode  bool
 	searchInput textinput.Model
 	showHelp    bool
+	locale      string
 	copied      bool
 	copyTimer   int
 
@@ -214,9 +257,56 @@ type Model struct {
 	toastType  string // "success", "error", "info", "warning"
 	toastTimer int
 
+	// Configuration
+	config    appConfig
+	configMod time.Time // modification time of the loaded file
+
+	// Personal catalog overlay and modal form
+	userCatalog userCatalog
+	team        teamCatalog
+	teamConfig  teamConfig
+	providers   []provider
+	here        hereContext
+	git         gitStatus
+	picker      Command // command the branch picker fills in
+	plugins     map[string][]catalogEntry // provider name -> entries
+	catPrefs    categoryPrefs
+	form        *inputForm
+	confirm     func(*Model) tea.Cmd // pending y/n action
+	menu        *contextMenu
+	pins        map[string]bool   // pinned commands, listed first
+	notes       map[string]string // Markdown note per command
+	note        *noteEditor
+	cmdline     *commandLine
+	cmdHistory  []string
+	theme       string // gradient for every category, "" for their own
+	showAllOS   bool   // also list commands unavailable on this OS
+	pwsh        string // detected PowerShell version, "" until known
+	elevation   string // privilege the process runs with
+	riskFilter  string // only this risk level, "" for all
+	privFilter  string // only this privilege or privRunnable, "" for all
+	tagFilter   tagFilter
+
 	// Statistics
 	totalCmds  int
 	usageStats map[string]int
+	history    historyStats
+	profile    profileIndex
+
+	// Pipeline composer and saved workflows
+	composer  []Command
+	joiner    int
+	workflows []Workflow
+	run       *workflowRun
+
+	// Learn mode
+	quiz  *quizState
+	cards map[string]Card
+
+	// Alternative screens (suggestions, ...)
+	screen      string
+	panelIndex  int
+	panelScroll int
 
 	// Dimensions
 	width  int
@@ -241,6 +331,8 @@ type Model struct {
 	// Detail view
 	detailScroll int
 	detailTab    int // 0: info, 1: examples, 2: related
+	detailFull   bool // detail pane fills the content area
+	splitRatio   int  // list share of the content, in percent
 
 	// Time
 	startTime time.Time
@@ -369,6 +461,7 @@ func initData() []Category {
 					Example: "fastcopy src/ dest/",
 					Usage: "fastcopy <source> <dest> [-t threads]",
 					Since: "v1.3",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "extract", Desc: "Extract any archive format",
@@ -390,6 +483,7 @@ func initData() []Category {
 					Example: "trash oldfile.txt",
 					Usage: "trash <files...> - Safe delete",
 					Since: "v1.0",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "open", Desc: "Open file or folder in Explorer",
@@ -397,6 +491,7 @@ func initData() []Category {
 					Example: "open .",
 					Usage: "open [path] - Opens in default app",
 					Since: "v1.0",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "tree2", Desc: "Enhanced directory tree view",
@@ -437,7 +532,7 @@ func initData() []Category {
 					Example: "killport 3000",
 					Usage: "killport <port-number>",
 					Since: "v1.1",
-					Danger: true,
+					Risk: riskDestructive,
 				},
 				{
 					Cmd: "myip", Desc: "Show public and local IP addresses",
@@ -485,6 +580,7 @@ func initData() []Category {
 					Example: "install vscode",
 					Usage: "install <package-name>",
 					Since: "v1.0",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "calc", Desc: "Quick mathematical calculator",
@@ -553,7 +649,9 @@ func initData() []Category {
 					Example: "sudo netstat -ab",
 					Usage: "sudo <command>",
 					Since: "v1.0",
-					Danger: true,
+					Risk: riskCaution,
+					Privilege: privAdmin,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "god", Desc: "Enter SYSTEM level God Mode",
@@ -561,7 +659,9 @@ func initData() []Category {
 					Example: "god",
 					Usage: "Elevates to NT AUTHORITY\\SYSTEM",
 					Since: "v1.0",
-					Danger: true,
+					Risk: riskSystem,
+					Privilege: privAdmin,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "ti", Desc: "Get TrustedInstaller privileges",
@@ -569,7 +669,9 @@ func initData() []Category {
 					Example: "ti",
 					Usage: "Ultimate Windows privileges",
 					Since: "v1.0",
-					Danger: true,
+					Risk: riskSystem,
+					Privilege: privSystem,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "drop", Desc: "Drop to normal user privileges",
@@ -577,6 +679,7 @@ func initData() []Category {
 					Example: "drop",
 					Usage: "Returns to normal user context",
 					Since: "v1.0",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "def", Desc: "Toggle Windows Defender on/off",
@@ -584,7 +687,9 @@ func initData() []Category {
 					Example: "def off",
 					Usage: "def [on|off]",
 					Since: "v1.1",
-					Danger: true,
+					Risk: riskSystem,
+					Privilege: privAdmin,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "avkill", Desc: "Terminate antivirus processes",
@@ -592,7 +697,9 @@ func initData() []Category {
 					Example: "avkill",
 					Usage: "Forces AV shutdown",
 					Since: "v1.2",
-					Danger: true,
+					Risk: riskDestructive,
+					Privilege: privSystem,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "nuke", Desc: "Force terminate any process",
@@ -600,7 +707,8 @@ func initData() []Category {
 					Example: "nuke notepad",
 					Usage: "nuke <process-name|pid>",
 					Since: "v1.0",
-					Danger: true,
+					Risk: riskDestructive,
+					Privilege: privAdmin,
 				},
 				{
 					Cmd: "ghost", Desc: "Clear all system logs and traces",
@@ -608,7 +716,9 @@ func initData() []Category {
 					Example: "ghost",
 					Usage: "Clears event logs, temp, history",
 					Since: "v1.2",
-					Danger: true,
+					Risk: riskDestructive,
+					Privilege: privAdmin,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "powerup", Desc: "Enable all token privileges",
@@ -616,7 +726,9 @@ func initData() []Category {
 					Example: "powerup",
 					Usage: "Enables SeDebugPrivilege, etc.",
 					Since: "v1.0",
-					Danger: true,
+					Risk: riskSystem,
+					Privilege: privAdmin,
+					Platforms: []string{"windows"},
 				},
 			},
 		},
@@ -629,6 +741,7 @@ func initData() []Category {
 					Example: "star notepad",
 					Usage: "star <window-title>",
 					Since: "v1.1",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "unstar", Desc: "Unpin window",
@@ -636,6 +749,7 @@ func initData() []Category {
 					Example: "unstar notepad",
 					Usage: "unstar <window-title>",
 					Since: "v1.1",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "wm", Desc: "Window manager for tiling",
@@ -643,6 +757,7 @@ func initData() []Category {
 					Example: "wm tile",
 					Usage: "wm [tile|cascade|stack]",
 					Since: "v1.2",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "hyp", Desc: "Check Hypervisor status",
@@ -650,6 +765,7 @@ func initData() []Category {
 					Example: "hyp",
 					Usage: "Checks Hyper-V, VMware, VBox",
 					Since: "v1.0",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "uefi", Desc: "Display UEFI/BIOS information",
@@ -657,6 +773,7 @@ func initData() []Category {
 					Example: "uefi",
 					Usage: "Shows firmware details",
 					Since: "v1.0",
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "vmx", Desc: "Inject commands into VM",
@@ -664,7 +781,9 @@ func initData() []Category {
 					Example: "vmx run 'dir'",
 					Usage: "vmx [run|file] <cmd|path>",
 					Since: "v1.3",
-					Danger: true,
+					Risk: riskCaution,
+					Privilege: privAdmin,
+					Platforms: []string{"windows"},
 				},
 				{
 					Cmd: "cmd", Desc: "Command palette launcher",
@@ -781,6 +900,11 @@ func initData() []Category {
 					Usage: "gd [ref]",
 					Since: "v1.0",
 				},
+			},
+		},
+		{
+			ID: "git-branch", Name: "Branching", Icon: "🌿", Gradient: "emerald", Parent: "git",
+			Commands: []Command{
 				{
 					Cmd: "gb", Desc: "List and manage branches",
 					Tags: []string{"branch", "list"},
@@ -795,6 +919,11 @@ func initData() []Category {
 					Usage: "gco <branch> [-b new]",
 					Since: "v1.0",
 				},
+			},
+		},
+		{
+			ID: "git-stash", Name: "Stash", Icon: "📦", Gradient: "emerald", Parent: "git",
+			Commands: []Command{
 				{
 					Cmd: "gst", Desc: "Stash current changes",
 					Tags: []string{"stash", "save", "temporary"},
@@ -812,9 +941,11 @@ func initData() []Category {
 // ══════════════════════════════════════════════════════════════════
 
 func newModel() Model {
+	cfg, cfgMod, cfgErr := loadConfig(configPath())
+
 	ti := textinput.New()
-	ti.Placeholder = "✨ Type to search commands..."
-	ti.CharLimit = 64
+	ti.Placeholder = cfg.SearchPlaceholder
+	ti.CharLimit = cfg.SearchCharLimit
 	ti.Width = 40
 	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary))
 	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
@@ -822,21 +953,86 @@ func newModel() Model {
 	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent))
 
 	m := Model{
-		categories:  initData(),
 		searchInput: ti,
+		locale:      detectLocale(cfg.Locale),
 		hoverCat:    -1,
 		hoverItem:   -1,
 		hitBoxes:    make([]HitBox, 0, 64),
 		usageStats:  make(map[string]int),
 		startTime:   time.Now(),
+		config:      cfg,
+		configMod:   cfgMod,
+	}
+	if cfgErr != nil {
+		m.showToast(fmt.Sprintf("Config: %v", cfgErr), "error")
 	}
 
-	// Calculate total commands
-	for _, cat := range m.categories {
-		m.totalCmds += len(cat.Commands)
+	prefs, err := loadCategoryPrefs()
+	if err != nil {
+		m.showToast(fmt.Sprintf("Category order: %v", err), "error")
 	}
+	m.catPrefs = prefs
 
-	m.updateFiltered()
+	uc, err := loadUserCatalog()
+	if err != nil {
+		m.showToast(fmt.Sprintf("User catalog: %v", err), "error")
+	}
+	m.userCatalog = uc
+
+	if usage, err := loadUsage(); err == nil {
+		m.usageStats = usage
+	}
+
+	pins, err := loadPins()
+	if err != nil {
+		m.showToast(fmt.Sprintf("Pins: %v", err), "error")
+	}
+	m.pins = pins
+
+	notes, err := loadNotes()
+	if err != nil {
+		m.showToast(fmt.Sprintf("Notes: %v", err), "error")
+	}
+	m.notes = notes
+
+	cmdHistory, err := loadCmdHistory()
+	if err != nil {
+		m.showToast(fmt.Sprintf("Command history: %v", err), "error")
+	}
+	m.cmdHistory = cmdHistory
+
+	m.teamConfig = teamConfigFromEnv()
+	m.loadCachedTeam()
+	m.providers = discoverProviders()
+	if wd, err := os.Getwd(); err == nil {
+		m.here = detectHere(wd)
+	}
+	m.reloadCatalog()
+	if id := m.config.DefaultCategory; id != "" {
+		if gi := categoryIndex(m.categories, id); gi >= 0 {
+			m.catIndex = gi
+			m.resetSelection()
+		} else {
+			m.showToast(fmt.Sprintf("Config: unknown default category %q", id), "warning")
+		}
+	} else if m.here.GitRoot != "" {
+		if gi := categoryIndex(m.categories, "git"); gi >= 0 {
+			m.catIndex = gi
+			m.resetSelection()
+		}
+	}
+
+	wfs, err := loadWorkflows()
+	if err != nil {
+		m.showToast(fmt.Sprintf("Workflows: %v", err), "error")
+	}
+	m.workflows = wfs
+
+	cards, err := loadLearn()
+	if err != nil {
+		m.showToast(fmt.Sprintf("Learn progress: %v", err), "error")
+	}
+	m.cards = cards
 	return m
 }
 
@@ -845,11 +1041,20 @@ func (m Model) Init() tea.Cmd {
 		textinput.Blink,
 		tea.EnableMouseAllMotion,
 		m.tickCmd(),
+		loadHistoryCmd,
+		loadProfileCmd,
+		m.teamFetchCmd(),
+		loadProvidersCmds(m.providers),
+		detectPwshCmd,
+		detectElevationCmd,
+		m.refreshGitCmd(),
+		m.gitTickCmd(),
+		configPollCmd(configPath(), m.configMod),
 	)
 }
 
 func (m Model) tickCmd() tea.Cmd {
-	return tea.Tick(time.Millisecond*80, func(t time.Time) tea.Msg {
+	return tea.Tick(time.Duration(m.config.Tick), func(t time.Time) tea.Msg {
 		return TickMsg(t)
 	})
 }
@@ -880,29 +1085,79 @@ func (m *Model) calculateLayout() {
 		m.layout.TabW = (m.width - 8) / 8
 	}
 
-	// Calculate rows needed for tabs
-	tabRows := (len(m.categories) + m.layout.TabsPerRow - 1) / m.layout.TabsPerRow
+	// Calculate rows needed for tabs; the tree sidebar replaces them
+	tabRows := (len(m.tabEntries()) + m.layout.TabsPerRow - 1) / m.layout.TabsPerRow
+	m.layout.SideW = 0
+	if m.catPrefs.Sidebar && m.width >= minSidebarWidth {
+		m.layout.SideW = sidebarW
+	}
 
 	// Fixed heights
 	m.layout.HeaderH = 8
 	m.layout.TabsH = tabRows + 1
+	if m.layout.SideW > 0 {
+		m.layout.TabsH = 0
+	}
 	m.layout.SearchH = 5
 	m.layout.StatusH = 2
+	m.layout.TrayH = 0
+	if len(m.composer) > 0 {
+		m.layout.TrayH = 1
+	}
 
 	// Content height
-	m.layout.ContentH = m.height - m.layout.HeaderH - m.layout.TabsH - m.layout.SearchH - m.layout.StatusH - 2
-
-	// List and detail widths
-	contentW := m.width - (m.layout.Padding * 2) - 2
-	if isSmall {
-		m.layout.ListW = contentW
-		m.layout.DetailW = 0
-	} else {
-		m.layout.ListW = contentW * 45 / 100
+	m.layout.ContentH = m.height - m.layout.HeaderH - m.layout.TabsH - m.layout.SearchH - m.layout.StatusH - m.layout.TrayH - 2
+
+	// List and detail panes
+	contentW := m.width - (m.layout.Padding * 2) - 2 - m.layout.SideW
+	contentX := m.layout.Padding + m.layout.SideW
+	isSmall = m.width-m.layout.SideW < 100
+	contentTop := m.layout.HeaderH + m.layout.TabsH + m.layout.SearchH
+	ratio := m.splitRatio
+	if ratio == 0 {
+		ratio = m.config.SplitRatio
+	}
+
+	switch {
+	case m.detailFull:
+		m.layout.Mode = layoutDetail
+		m.layout.ListW, m.layout.ListH = 0, 0
+		m.layout.DetailW, m.layout.DetailH = contentW, m.layout.ContentH
+		m.layout.DetailX, m.layout.DetailY = contentX, contentTop
+
+	case !isSmall:
+		m.layout.Mode = layoutSplit
+		m.layout.ListW = contentW * ratio / 100
 		m.layout.DetailW = contentW - m.layout.ListW - 2
+		m.layout.ListH, m.layout.DetailH = m.layout.ContentH, m.layout.ContentH
+		m.layout.DetailX, m.layout.DetailY = contentX+m.layout.ListW+1, contentTop
+
+	case m.layout.ContentH >= minStackedH:
+		// Narrow: stack the detail pane below the list
+		m.layout.Mode = layoutStacked
+		m.layout.ListW, m.layout.DetailW = contentW, contentW
+		m.layout.ListH = max(6, m.layout.ContentH*ratio/100)
+		m.layout.DetailH = m.layout.ContentH - m.layout.ListH
+		m.layout.DetailX, m.layout.DetailY = contentX, contentTop+m.layout.ListH
+
+	default:
+		m.layout.Mode = layoutListOnly
+		m.layout.ListW, m.layout.ListH = contentW, m.layout.ContentH
+		m.layout.DetailW, m.layout.DetailH = 0, 0
 	}
 }
 
+// adjustSplit changes the list/detail split ratio by delta percent.
+func (m *Model) adjustSplit(delta int) {
+	if m.splitRatio == 0 {
+		m.splitRatio = m.config.SplitRatio
+	}
+	m.splitRatio = max(minSplitRatio, min(maxSplitRatio, m.splitRatio+delta))
+	m.calculateLayout()
+	m.adjustScroll()
+	m.showToast(fmt.Sprintf("Split: %d%% list", m.splitRatio), "info")
+}
+
 func (m *Model) recalcHitBoxes() {
 	m.hitBoxes = m.hitBoxes[:0]
 
@@ -911,16 +1166,29 @@ func (m *Model) recalcHitBoxes() {
 	tabW := m.layout.TabW
 	perRow := m.layout.TabsPerRow
 
-	for i := range m.categories {
-		col := i % perRow
-		row := i / perRow
-		x := pad + col*(tabW-1)
-		y := m.layout.HeaderH + row + 1
+	if m.layout.TabsH > 0 {
+		for i, e := range m.tabEntries() {
+			col := i % perRow
+			row := i / perRow
+			x := pad + col*(tabW-1)
+			y := m.layout.HeaderH + row + 1
 
-		m.hitBoxes = append(m.hitBoxes, HitBox{
-			X: x, Y: y, W: tabW - 1, H: 1,
-			Type: "cat", Index: i,
-		})
+			m.hitBoxes = append(m.hitBoxes, HitBox{
+				X: x, Y: y, W: tabW - 1, H: 1,
+				Type: "cat", Index: e.Index,
+			})
+		}
+	}
+
+	// Sidebar
+	if m.layout.SideW > 0 {
+		y := m.layout.HeaderH + m.layout.SearchH + 1
+		for i, r := range m.sidebarRows() {
+			m.hitBoxes = append(m.hitBoxes, HitBox{
+				X: 1, Y: y + i, W: sidebarW - 2, H: 1,
+				Type: "cat", Index: r.Index,
+			})
+		}
 	}
 
 	// Search
@@ -933,13 +1201,13 @@ func (m *Model) recalcHitBoxes() {
 
 	// List
 	listStartY := m.layout.HeaderH + m.layout.TabsH + m.layout.SearchH + 1
-	visible := m.layout.ContentH - 3
+	visible := m.layout.ListH - 3
 
 	for i := 0; i < visible; i++ {
 		idx := i + m.scrollY
 		if idx < len(m.filtered) {
 			m.hitBoxes = append(m.hitBoxes, HitBox{
-				X: m.layout.Padding, Y: listStartY + i,
+				X: m.layout.Padding + m.layout.SideW, Y: listStartY + i,
 				W: m.layout.ListW - 1, H: 1,
 				Type: "item", Index: idx,
 			})
@@ -947,40 +1215,17 @@ func (m *Model) recalcHitBoxes() {
 	}
 
 	// Detail (Button)
-	if m.layout.DetailW > 0 && m.itemIndex < len(m.filtered) {
-		item := m.filtered[m.itemIndex]
-		linesCount := 0
-		linesCount += 1 // Empty
-		linesCount += 1 // COMMAND label
-		linesCount += 1 // Command
-		linesCount += 1 // Empty
-		linesCount += 1 // DESCRIPTION label
-
-		desc := item.Desc
-		maxDescW := m.layout.DetailW - 6
-		if len(desc) > maxDescW {
-			linesCount += 2
-		} else {
-			linesCount += 1
-		}
-		linesCount += 1 // Empty
-
-		if item.Hot != "" {
-			linesCount += 3 // LABEL + HOTKEY + Empty
-		}
-
-		if len(item.Tags) > 0 {
-			linesCount += 3 // LABEL + TAGS + Empty
+	if m.layout.DetailW > 0 && m.detailTab == 0 && m.itemIndex < len(m.filtered) {
+		lines, buttonLine := m.detailLines(m.layout.DetailW)
+		visible := m.layout.DetailH - 3
+		buttonLine -= m.detailOffset(len(lines), visible)
+		if buttonLine >= 0 && buttonLine < visible {
+			m.hitBoxes = append(m.hitBoxes, HitBox{
+				X: m.layout.DetailX + 3, Y: m.layout.DetailY + 1 + buttonLine,
+				W: 24, H: 1,
+				Type: "btn", ID: "copy",
+			})
 		}
-
-		linesCount += 1 // Empty before button
-
-		buttonY := m.layout.HeaderH + m.layout.TabsH + m.layout.SearchH + 1 + linesCount
-		m.hitBoxes = append(m.hitBoxes, HitBox{
-			X: m.layout.Padding + m.layout.ListW + 4, Y: buttonY,
-			W: 24, H: 1,
-			Type: "btn", ID: "copy",
-		})
 	}
 
 	// Help Button (Status)
@@ -992,35 +1237,35 @@ func (m *Model) recalcHitBoxes() {
 }
 
 func (m *Model) updateFiltered() {
-	cat := m.categories[m.catIndex]
-	query := strings.ToLower(strings.TrimSpace(m.searchInput.Value()))
+	query := m.searchInput.Value()
+
+	cmds := m.categoryCommands(m.catIndex)
+	if m.tagFilter.active() {
+		cmds = m.taggedCommands()
+	}
+	m.filtered = searchCommands(m.sortPinned(m.riskFiltered(m.availableOnly(cmds))), query, m.notes)
+}
 
+// searchCommands returns the commands matching query or whose note does,
+// once each, in the order given. An empty query matches everything.
+func searchCommands(cmds []Command, query string, notes map[string]string) []Command {
+	query = strings.ToLower(strings.TrimSpace(query))
 	if query == "" {
-		m.filtered = cat.Commands
-		return
+		return cmds
 	}
 
 	seen := make(map[string]bool)
-	m.filtered = nil
-
-	for _, cmd := range cat.Commands {
+	var out []Command
+	for _, cmd := range cmds {
 		if seen[cmd.Cmd] {
 			continue
 		}
-		if strings.Contains(strings.ToLower(cmd.Cmd), query) ||
-			strings.Contains(strings.ToLower(cmd.Desc), query) {
-			m.filtered = append(m.filtered, cmd)
+		if cmd.matchesQuery(query) || strings.Contains(strings.ToLower(notes[cmd.Cmd]), query) {
+			out = append(out, cmd)
 			seen[cmd.Cmd] = true
-			continue
-		}
-		for _, tag := range cmd.Tags {
-			if strings.Contains(strings.ToLower(tag), query) {
-				m.filtered = append(m.filtered, cmd)
-				seen[cmd.Cmd] = true
-				break
-			}
 		}
 	}
+	return out
 }
 
 // ══════════════════════════════════════════════════════════════════
@@ -1037,6 +1282,15 @@ func getGradient(name string) []string {
 	return gradients["cyber"]
 }
 
+// gradientOf returns the gradient to draw cat with: the theme set with
+// :theme, or the category's own.
+func (m *Model) gradientOf(cat Category) string {
+	if m.theme != "" {
+		return m.theme
+	}
+	return cat.Gradient
+}
+
 func lerpColor(colors []string, t float64) string {
 	if len(colors) == 0 {
 		return "#FFFFFF"
@@ -1117,6 +1371,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.tickCmd()
 
+	case configReloadMsg:
+		if msg.Changed {
+			m.handleConfigReload(msg)
+		}
+		return m, configPollCmd(configPath(), m.configMod)
+
+	case historyLoadedMsg:
+		m.history = historyStats(msg)
+		return m, nil
+
+	case execDoneMsg:
+		m.handleExecDone(msg)
+		return m, m.refreshGitCmd()
+
+	case profileLoadedMsg:
+		m.profile = profileIndex(msg)
+		return m, nil
+
+	case elevationMsg:
+		m.elevation = string(msg)
+		if m.privFilter == privRunnable {
+			m.resetSelection()
+		}
+		return m, nil
+
+	case pwshVersionMsg:
+		m.pwsh = string(msg)
+		m.updateFiltered()
+		m.itemIndex = min(m.itemIndex, max(0, len(m.filtered)-1))
+		m.adjustScroll()
+		return m, nil
+
+	case teamLoadedMsg:
+		m.handleTeamLoaded(msg)
+		return m, nil
+
+	case providerLoadedMsg:
+		m.handleProviderLoaded(msg)
+		return m, nil
+
+	case gitStatusMsg:
+		m.git = gitStatus(msg)
+		return m, nil
+
+	case gitRefreshMsg:
+		if m.inGitCategory() || m.screen == screenBranches {
+			return m, tea.Batch(m.refreshGitCmd(), gitRefreshTick())
+		}
+		return m, gitRefreshTick()
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -1127,16 +1431,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		newM, cmd := m.handleKey(msg)
 		finalM := newM.(Model)
+		finalM.resetDetailScroll(m)
 		finalM.recalcHitBoxes()
 		return finalM, cmd
 
 	case tea.MouseMsg:
 		newM, cmd := m.handleMouse(msg)
 		finalM := newM.(Model)
+		finalM.resetDetailScroll(m)
 		finalM.recalcHitBoxes()
 		return finalM, cmd
 	}
 
+	if m.form != nil {
+		var cmd tea.Cmd
+		f := m.form
+		f.Fields[f.Focus].Input, cmd = f.Fields[f.Focus].Input.Update(msg)
+		return m, cmd
+	}
+
+	if m.note != nil {
+		var cmd tea.Cmd
+		m.note.Area, cmd = m.note.Area.Update(msg)
+		return m, cmd
+	}
+
+	if m.cmdline != nil {
+		var cmd tea.Cmd
+		m.cmdline.Input, cmd = m.cmdline.Input.Update(msg)
+		return m, cmd
+	}
+
+	if m.screen == screenLearn && m.quiz != nil {
+		var cmd tea.Cmd
+		m.quiz.Input, cmd = m.quiz.Input.Update(msg)
+		return m, cmd
+	}
+
 	if m.searchMode {
 		var cmd tea.Cmd
 		m.searchInput, cmd = m.searchInput.Update(msg)
@@ -1184,12 +1515,53 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Form overlay
+	if m.form != nil {
+		return m.handleFormKey(msg)
+	}
+
+	// Note editor
+	if m.note != nil {
+		return m.handleNoteKey(msg)
+	}
+
+	// Command line
+	if m.cmdline != nil {
+		return m.handleCommandLineKey(msg)
+	}
+
+	// Context menu
+	if m.menu != nil {
+		return m.handleMenuKey(key)
+	}
+
+	// Pending y/n confirmation
+	if m.confirm != nil {
+		fn := m.confirm
+		m.confirm = nil
+		if key == "y" || key == "Y" {
+			return m, fn(&m)
+		}
+		m.showToast("Cancelled", "info")
+		return m, nil
+	}
+
 	// Help mode
 	if m.showHelp {
 		m.showHelp = false
 		return m, nil
 	}
 
+	// Learn mode reads typed answers, so it takes every key
+	if m.screen == screenLearn {
+		return m.handleLearnKey(msg)
+	}
+
+	// Alternative screens take all keys except quit
+	if m.screen != screenCatalog && key != "q" && key != "ctrl+c" {
+		return m.handleScreenKey(key)
+	}
+
 	maxItem := len(m.filtered) - 1
 
 	switch key {
@@ -1208,21 +1580,31 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.adjustScroll()
 		}
 
-	case "left", "h":
-		m.catIndex = (m.catIndex - 1 + len(m.categories)) % len(m.categories)
-		m.resetSelection()
+	case "left", "h", "shift+tab":
+		m.stepCategory(-1)
+
+	case "right", "l", "tab":
+		m.stepCategory(1)
 
-	case "right", "l":
-		m.catIndex = (m.catIndex + 1) % len(m.categories)
-		m.resetSelection()
+	case "b":
+		m.toggleSidebar()
 
-	case "tab":
-		m.catIndex = (m.catIndex + 1) % len(m.categories)
-		m.resetSelection()
+	case "z":
+		m.foldCurrent()
 
-	case "shift+tab":
-		m.catIndex = (m.catIndex - 1 + len(m.categories)) % len(m.categories)
-		m.resetSelection()
+	case "{":
+		m.moveCategory(-1)
+
+	case "}":
+		m.moveCategory(1)
+
+	case "@":
+		m.openJumpForm()
+		return m, textinput.Blink
+
+	case ":":
+		m.openCommandLine()
+		return m, textinput.Blink
 
 	case "/", "ctrl+f":
 		m.searchMode = true
@@ -1232,9 +1614,104 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "?", "f1":
 		m.showHelp = true
 
-	case "enter", " ":
+	case "L":
+		m.setLocale(nextLocale(m.locale))
+
+	case "S":
+		m.setScreen(screenSuggest)
+
+	case "C":
+		m.setScreen(screenConflicts)
+
+	case "n":
+		m.openCommandForm(false)
+		return m, textinput.Blink
+
+	case "e":
+		if m.itemIndex < len(m.filtered) {
+			m.openCommandForm(true)
+			return m, textinput.Blink
+		}
+
+	case "D", "delete":
+		if m.itemIndex < len(m.filtered) {
+			m.askConfirm(fmt.Sprintf("Delete %s?", m.filtered[m.itemIndex].Cmd), (*Model).deleteSelected)
+		}
+
+	case "enter":
 		m.doCopy()
 
+	case "y", "Y", "u", "r", "p", "o", "N":
+		if m.itemIndex < len(m.filtered) {
+			for _, a := range menuActions {
+				if a.Key == key {
+					return m, m.itemAction(a.ID, m.filtered[m.itemIndex])
+				}
+			}
+		}
+
+	case "m":
+		x, y := m.width/3, m.height/3
+		for _, hb := range m.hitBoxes {
+			if hb.Type == "item" && hb.Index == m.itemIndex {
+				x, y = hb.X+4, hb.Y+1
+			}
+		}
+		m.openMenu(x, y)
+
+	case " ":
+		if m.itemIndex < len(m.filtered) {
+			m.toggleComposed(m.filtered[m.itemIndex])
+		}
+
+	case "c":
+		m.setScreen(screenComposer)
+
+	case "s":
+		m.detailTab = 1 - m.detailTab
+		m.detailScroll = 0
+
+	case "K":
+		m.detailScroll = max(0, m.detailScroll-3)
+
+	case "J":
+		m.detailScroll += 3
+
+	case "f":
+		m.detailFull = !m.detailFull
+		m.detailScroll = 0
+		m.calculateLayout()
+
+	case "<":
+		m.adjustSplit(-5)
+
+	case ">":
+		m.adjustSplit(5)
+
+	case "W":
+		m.setScreen(screenWorkflows)
+
+	case "#":
+		m.setScreen(screenTags)
+
+	case "O":
+		m.toggleAllPlatforms()
+
+	case "!":
+		m.cycleRiskFilter()
+
+	case "^":
+		m.cyclePrivFilter()
+
+	case "R":
+		return m, m.refreshTeam()
+
+	case "P":
+		return m, m.refreshProviders()
+
+	case "T":
+		return m, m.startQuiz()
+
 	case "home", "g":
 		m.itemIndex = 0
 		m.scrollY = 0
@@ -1252,19 +1729,20 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.adjustScroll()
 
 	case "esc":
-		if m.searchInput.Value() != "" {
+		if m.detailFull {
+			m.detailFull = false
+			m.calculateLayout()
+		} else if m.searchInput.Value() != "" {
 			m.searchInput.Reset()
 			m.updateFiltered()
 			m.itemIndex = 0
 			m.scrollY = 0
+		} else if m.tagFilter.active() {
+			m.clearTagFilter()
 		}
 
 	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
-		idx := int(key[0] - '1')
-		if idx < len(m.categories) {
-			m.catIndex = idx
-			m.resetSelection()
-		}
+		m.selectRoot(int(key[0] - '1'))
 	}
 
 	return m, nil
@@ -1274,6 +1752,20 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	m.mouseX = msg.X
 	m.mouseY = msg.Y
 
+	if m.menu != nil {
+		switch msg.Type {
+		case tea.MouseLeft:
+			return m.menuClick(msg.X, msg.Y)
+		case tea.MouseRight:
+			m.menu = nil
+		case tea.MouseMotion:
+			m.menuHover(msg.X, msg.Y)
+			return m, nil
+		default:
+			return m, nil
+		}
+	}
+
 	// Reset hover
 	m.hoverCat = -1
 	m.hoverItem = -1
@@ -1304,11 +1796,16 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		m.lastClick = now
 
 		if m.hoverCat >= 0 {
-			m.catIndex = m.hoverCat
-			m.resetSelection()
+			if m.hoverCat == m.catIndex && m.layout.SideW > 0 {
+				m.toggleCollapsed(m.hoverCat)
+			}
+			m.selectCategory(m.hoverCat)
 		}
 
-		if m.hoverItem >= 0 {
+		if m.hoverItem >= 0 && msg.Shift {
+			m.toggleComposed(m.filtered[m.hoverItem])
+			m.itemIndex = m.hoverItem
+		} else if m.hoverItem >= 0 {
 			if m.hoverItem == m.itemIndex && m.doubleClick {
 				m.doCopy()
 			}
@@ -1329,13 +1826,25 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			m.showHelp = true
 		}
 
+	case tea.MouseRight:
+		if m.hoverItem >= 0 {
+			m.itemIndex = m.hoverItem
+			m.openMenu(msg.X, msg.Y)
+		}
+
 	case tea.MouseWheelUp:
-		if m.scrollY > 0 {
+		if m.overDetail() {
+			m.detailScroll = max(0, m.detailScroll-1)
+		} else if m.scrollY > 0 {
 			m.scrollY--
 		}
 
 	case tea.MouseWheelDown:
-		maxScroll := max(0, len(m.filtered)-m.layout.ContentH+4)
+		if m.overDetail() {
+			m.detailScroll++
+			break
+		}
+		maxScroll := max(0, len(m.filtered)-m.layout.ListH+4)
 		if m.scrollY < maxScroll {
 			m.scrollY++
 		}
@@ -1344,6 +1853,46 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleScreenKey routes keys to the active alternative screen.
+func (m Model) handleScreenKey(key string) (tea.Model, tea.Cmd) {
+	switch m.screen {
+	case screenSuggest:
+		return m.handleSuggestKey(key)
+	case screenConflicts:
+		return m.handleConflictsKey(key)
+	case screenComposer:
+		return m.handleComposerKey(key)
+	case screenWorkflows:
+		return m.handleWorkflowsKey(key)
+	case screenRunner:
+		return m.handleRunnerKey(key)
+	case screenBranches:
+		return m.handleBranchesKey(key)
+	case screenTags:
+		return m.handleTagsKey(key)
+	}
+	return m, nil
+}
+
+// resetDetailScroll scrolls the detail pane back to the top when the
+// selection changed since prev.
+func (m *Model) resetDetailScroll(prev Model) {
+	if m.catIndex != prev.catIndex || m.itemIndex != prev.itemIndex {
+		m.detailScroll = 0
+	}
+}
+
+// overDetail reports whether the mouse is over the detail pane.
+func (m *Model) overDetail() bool {
+	if m.layout.DetailW == 0 {
+		return false
+	}
+	if m.layout.Mode == layoutSplit {
+		return m.mouseX >= m.layout.DetailX
+	}
+	return m.mouseY >= m.layout.DetailY
+}
+
 func (m *Model) resetSelection() {
 	m.itemIndex = 0
 	m.scrollY = 0
@@ -1351,7 +1900,7 @@ func (m *Model) resetSelection() {
 }
 
 func (m *Model) adjustScroll() {
-	visible := m.layout.ContentH - 4
+	visible := m.layout.ListH - 4
 	if visible < 1 {
 		visible = 1
 	}
@@ -1365,24 +1914,61 @@ func (m *Model) adjustScroll() {
 
 func (m *Model) doCopy() {
 	if m.itemIndex < len(m.filtered) {
-		cmd := m.filtered[m.itemIndex].Cmd
-		err := clipboard.WriteAll(cmd)
-
-		if err == nil {
-			m.copied = true
-			m.copyTimer = 25
-			m.toast = fmt.Sprintf("Copied: %s", cmd)
-			m.toastType = "success"
-			m.toastTimer = 30
-
-			// Track usage
-			m.usageStats[cmd]++
-		} else {
-			m.toast = "Failed to copy!"
-			m.toastType = "error"
-			m.toastTimer = 30
-		}
+		m.copyCommand(m.filtered[m.itemIndex])
+	}
+}
+
+// copyCommand copies c, asking for a branch or placeholder values first
+// when it needs them.
+func (m *Model) copyCommand(c Command) {
+	if m.openBranchPicker(c) {
+		return
+	}
+	if len(placeholders(c.Cmd)) > 0 {
+		m.openPlaceholderForm(c)
+		return
 	}
+	m.copyText(c.Cmd, c.Cmd)
+}
+
+// copyText puts text on the clipboard and records a use of cmd.
+func (m *Model) copyText(text, cmd string) {
+	err := clipboard.WriteAll(text)
+
+	if err == nil {
+		m.copied = true
+		m.copyTimer = 25
+		m.showToast(fmt.Sprintf("Copied: %s", text), "success")
+
+		// Track usage, shared with the API server
+		m.usageStats[cmd]++
+		_, _ = recordUsage(cmd)
+	} else {
+		m.showToast("Failed to copy!", "error")
+	}
+}
+
+// askConfirm shows prompt and runs fn if the next key is y.
+func (m *Model) askConfirm(prompt string, fn func(*Model)) {
+	m.askConfirmCmd(prompt, func(m *Model) tea.Cmd {
+		fn(m)
+		return nil
+	})
+}
+
+// askConfirmCmd is askConfirm for an action returning a command.
+func (m *Model) askConfirmCmd(prompt string, fn func(*Model) tea.Cmd) {
+	m.confirm = fn
+	m.toast = prompt + " (y/n)"
+	m.toastType = "warning"
+	m.toastTimer = m.config.toastTicks("confirm")
+}
+
+// showToast displays a status-bar notification.
+func (m *Model) showToast(text, kind string) {
+	m.toast = text
+	m.toastType = kind
+	m.toastTimer = m.config.toastTicks(kind)
 }
 
 // ══════════════════════════════════════════════════════════════════
@@ -1520,15 +2106,35 @@ func (m Model) View() string {
 	var view strings.Builder
 
 	view.WriteString(m.viewHeader())
-	view.WriteString(m.viewTabs())
+	if m.layout.TabsH > 0 {
+		view.WriteString(m.viewTabs())
+	}
 	view.WriteString(m.viewSearch())
-	view.WriteString(m.viewContent())
+	if m.layout.SideW > 0 {
+		content := strings.TrimSuffix(m.viewContent(), "\n")
+		view.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, m.viewSidebar(), content) + "\n")
+	} else {
+		view.WriteString(m.viewContent())
+	}
+	view.WriteString(m.viewTray())
 	view.WriteString(m.viewStatus())
 
 	if m.showHelp {
 		return m.viewHelp()
 	}
 
+	if m.form != nil {
+		return m.viewForm()
+	}
+
+	if m.note != nil {
+		return m.viewNoteEditor()
+	}
+
+	if m.menu != nil {
+		return overlay(view.String(), m.viewMenu(), m.menu.X, m.menu.Y)
+	}
+
 	return view.String()
 }
 
@@ -1604,15 +2210,17 @@ func (m *Model) viewTabs() string {
 	tabW := m.layout.TabW
 	perRow := m.layout.TabsPerRow
 
-	for i, cat := range m.categories {
+	entries := m.tabEntries()
+	for i, e := range entries {
 		col := i % perRow
+		cat := m.categories[e.Index]
 
-		isSelected := i == m.catIndex
-		isHovered := i == m.hoverCat
-		grad := getGradient(cat.Gradient)
+		isSelected := e.Index == m.catIndex
+		isHovered := e.Index == m.hoverCat
+		grad := getGradient(m.gradientOf(cat))
 
-		// Count commands in category
-		cmdCount := len(cat.Commands)
+		// Count commands in category and its subcategories
+		cmdCount := len(m.categoryCommands(e.Index))
 
 		var style lipgloss.Style
 		var indicator string
@@ -1643,8 +2251,10 @@ func (m *Model) viewTabs() string {
 
 		// Format: indicator + numKey + icon + name + count
 		numKey := ""
-		if i < 9 {
-			numKey = fmt.Sprintf("%d:", i+1)
+		if n := m.rootNumber(e.Index); n > 0 && n <= 9 {
+			numKey = fmt.Sprintf("%d:", n)
+		} else if e.Depth > 0 {
+			numKey = "↳"
 		}
 
 		countBadge := lipgloss.NewStyle().
@@ -1663,14 +2273,14 @@ func (m *Model) viewTabs() string {
 
 		tabs.WriteString(style.Width(tabW - 1).Render(displayContent))
 
-		if col == perRow-1 || i == len(m.categories)-1 {
+		if col == perRow-1 || i == len(entries)-1 {
 			tabs.WriteString("\n")
 		}
 	}
 
 	// Bottom border with current category highlight
 	cat := m.categories[m.catIndex]
-	bottomBorder := sparkleBorder(m.width-4, m.frame, cat.Gradient)
+	bottomBorder := sparkleBorder(m.width-4, m.frame, m.gradientOf(cat))
 	tabs.WriteString(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, bottomBorder) + "\n")
 
 	return tabs.String()
@@ -1681,7 +2291,7 @@ func (m *Model) viewSearch() string {
 	isHovered := m.hoverBtn == "search"
 
 	cat := m.categories[m.catIndex]
-	grad := getGradient(cat.Gradient)
+	grad := getGradient(m.gradientOf(cat))
 
 	borderColor := colors.border
 	if isActive {
@@ -1759,11 +2369,40 @@ func (m *Model) viewSearch() string {
 }
 
 func (m *Model) viewContent() string {
-	if m.layout.DetailW == 0 {
+	switch m.screen {
+	case screenSuggest:
+		return m.viewSuggestions()
+	case screenConflicts:
+		return m.viewConflicts()
+	case screenComposer:
+		return m.viewComposer()
+	case screenWorkflows:
+		return m.viewWorkflows()
+	case screenRunner:
+		return m.viewRunner()
+	case screenLearn:
+		return m.viewLearn()
+	case screenBranches:
+		return m.viewBranches()
+	case screenTags:
+		return m.viewTags()
+	}
+
+	switch m.layout.Mode {
+	case layoutListOnly:
 		// Small screen: only list
 		return lipgloss.NewStyle().
 			MarginLeft(m.layout.Padding).
 			Render(m.viewList())
+
+	case layoutDetail:
+		return lipgloss.NewStyle().MarginLeft(m.layout.Padding).Render(m.viewDetail()) + "\n"
+
+	case layoutStacked:
+		return lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().MarginLeft(m.layout.Padding).Render(m.viewList()),
+			lipgloss.NewStyle().MarginLeft(m.layout.Padding).Render(m.viewDetail()),
+		) + "\n"
 	}
 
 	// Normal: list + detail
@@ -1778,14 +2417,21 @@ func (m *Model) viewContent() string {
 
 func (m *Model) viewList() string {
 	cat := m.categories[m.catIndex]
-	grad := getGradient(cat.Gradient)
-	height := m.layout.ContentH
+	grad := getGradient(m.gradientOf(cat))
+	height := m.layout.ListH
 
 	var s strings.Builder
 
 	// Enhanced header with animated border
 	title := fmt.Sprintf(" %s %s ", cat.Icon, cat.Name)
 	cmdCount := fmt.Sprintf(" %d cmds ", len(cat.Commands))
+	if m.tagFilter.active() {
+		title = fmt.Sprintf(" 🏷  %s ", m.tagFilter)
+		cmdCount = fmt.Sprintf(" %d cmds ", len(m.filtered))
+	}
+	if label := m.filterLabel(); label != "" {
+		cmdCount = fmt.Sprintf(" %s · %d cmds ", label, len(m.filtered))
+	}
 
 	headerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(grad[0])).
@@ -1806,7 +2452,7 @@ func (m *Model) viewList() string {
 
 	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(grad[0])).Render(cornerAnim + "─"))
 	s.WriteString(headerStyle.Render(title))
-	s.WriteString(gradientStr(strings.Repeat("─", padLen), cat.Gradient))
+	s.WriteString(gradientStr(strings.Repeat("─", padLen), m.gradientOf(cat)))
 	s.WriteString(countStyle.Render(cmdCount))
 	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(grad[len(grad)-1])).Render("─" + cornerAnim))
 	s.WriteString("\n")
@@ -1843,10 +2489,27 @@ func (m *Model) viewList() string {
 				icon = "•"
 			}
 
-			// Danger indicator
-			dangerMark := ""
-			if item.Danger {
-				dangerMark = "⚠"
+			// Risk and privilege badges
+			dangerMark := riskMarks(item)
+
+			// Custom (overlay) indicator
+			customMark := ""
+			if item.Source == sourceUser {
+				customMark = " ✎"
+			} else if item.Source == sourceTeam {
+				customMark = " ⚑"
+			} else if strings.HasPrefix(item.Source, sourcePluginPrefix) {
+				customMark = " ⚙"
+			}
+			if m.pins[item.Cmd] {
+				customMark += " ★"
+			}
+			if m.notes[item.Cmd] != "" {
+				customMark += " ¶"
+			}
+			unavailable := !m.available(item)
+			if unavailable {
+				customMark += " ⊘"
 			}
 
 			var itemStyle lipgloss.Style
@@ -1875,6 +2538,16 @@ func (m *Model) viewList() string {
 				indicator = "  "
 				iconStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
 			}
+			if unavailable && !isSelected {
+				// Greyed out: doesn't work on this platform
+				itemStyle = itemStyle.Foreground(lipgloss.Color(colors.textMuted)).Faint(true)
+				iconStyle = iconStyle.Foreground(lipgloss.Color(colors.textMuted))
+			}
+
+			// Composer selection indicator
+			if m.isComposed(item.Cmd) {
+				indicator = "◉ "
+			}
 
 			// Truncate command name
 			cmdDisplay := item.Cmd
@@ -1883,11 +2556,12 @@ func (m *Model) viewList() string {
 				cmdDisplay = cmdDisplay[:maxLen-1] + "…"
 			}
 
-			content := fmt.Sprintf("%s%s %s%s",
+			content := fmt.Sprintf("%s%s %s%s%s",
 				indicator,
 				iconStyle.Render(icon),
 				cmdDisplay,
-				lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning)).Render(dangerMark))
+				dangerMark,
+				lipgloss.NewStyle().Foreground(lipgloss.Color(colors.secondary)).Render(customMark))
 
 			s.WriteString(itemStyle.Render(content))
 		} else {
@@ -1919,42 +2593,19 @@ func (m *Model) viewList() string {
 
 	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(grad[len(grad)-1])).Render(cornerAnim + "─"))
 	s.WriteString(progressBar)
-	s.WriteString(gradientStr(strings.Repeat("─", footerPad), cat.Gradient))
+	s.WriteString(gradientStr(strings.Repeat("─", footerPad), m.gradientOf(cat)))
 	s.WriteString(statsStyle.Render(stats))
 	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(grad[0])).Render("─" + cornerAnim))
 
 	return s.String()
 }
 
-func (m *Model) viewDetail() string {
-	cat := m.categories[m.catIndex]
-	grad := getGradient(cat.Gradient)
-	height := m.layout.ContentH
-	width := m.layout.DetailW
+// detailLines builds the content lines of the detail pane for the given
+// width. buttonLine is the index of the copy button, or -1.
+func (m *Model) detailLines(width int) (lines []string, buttonLine int) {
+	grad := getGradient(m.gradientOf(m.categories[m.catIndex]))
 
-	var s strings.Builder
-
-	// Animated header
-	sparkle := sparkles[m.frame%len(sparkles)]
-	title := fmt.Sprintf(" %s Command Details %s ", sparkle, sparkle)
-
-	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(grad[len(grad)-1])).
-		Bold(true)
-
-	headerLen := lipgloss.Width(title)
-	padLen := width - headerLen - 4
-	if padLen < 0 {
-		padLen = 0
-	}
-
-	s.WriteString(gradientStr("╭─", cat.Gradient))
-	s.WriteString(headerStyle.Render(title))
-	s.WriteString(gradientStr(strings.Repeat("─", padLen)+"╮", cat.Gradient))
-	s.WriteString("\n")
-
-	// Content lines
-	var lines []string
+	buttonLine = -1
 
 	if m.itemIndex < len(m.filtered) {
 		item := m.filtered[m.itemIndex]
@@ -1967,167 +2618,273 @@ func (m *Model) viewDetail() string {
 
 		lines = append(lines, "")
 
-		// ═══════════ COMMAND SECTION ═══════════
-		sectionHeader := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colors.textMuted)).
-			Bold(true)
+		if m.detailTab == 1 {
+			lines = append(lines, m.sourceLines(item, width)...)
+		} else {
 
-		lines = append(lines, sectionHeader.Render("  ┌─── COMMAND ───┐"))
+			// ═══════════ REPOSITORY ═══════════
+			if m.inGitCategory() {
+				if repo := m.gitStatusLines(width); len(repo) > 0 {
+					lines = append(lines, repo...)
+					lines = append(lines, "")
+				}
+			}
 
-		// Command with icon - large display
-		cmdStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(grad[0])).
-			Bold(true)
+			// ═══════════ COMMAND SECTION ═══════════
+			sectionHeader := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(colors.textMuted)).
+				Bold(true)
 
-		lines = append(lines, fmt.Sprintf("  │ %s  %s", icon, cmdStyle.Render(item.Cmd)))
-		lines = append(lines, sectionHeader.Render("  └──────────────────┘"))
-		lines = append(lines, "")
+			lines = append(lines, sectionHeader.Render("  ┌─── COMMAND ───┐"))
 
-		// ═══════════ DESCRIPTION ═══════════
-		lines = append(lines, sectionHeader.Render("  ┌─── DESCRIPTION ───┐"))
+			// Command with icon - large display
+			cmdStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(grad[0])).
+				Bold(true)
 
-		// Word wrap description nicely
-		desc := item.Desc
-		maxDescW := width - 8
-		descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+			lines = append(lines, fmt.Sprintf("  │ %s  %s", icon, cmdStyle.Render(item.Cmd)))
+			lines = append(lines, sectionHeader.Render("  └──────────────────┘"))
+			lines = append(lines, "")
 
-		words := strings.Fields(desc)
-		currentLine := "  │ "
-		for _, word := range words {
-			if len(currentLine)+len(word)+1 > maxDescW {
+			// ═══════════ DESCRIPTION ═══════════
+			lines = append(lines, sectionHeader.Render("  ┌─── DESCRIPTION ───┐"))
+
+			// Word wrap description nicely
+			desc := item.Description(m.locale)
+			maxDescW := width - 8
+			descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+
+			words := strings.Fields(desc)
+			currentLine := "  │ "
+			for _, word := range words {
+				if len(currentLine)+len(word)+1 > maxDescW {
+					lines = append(lines, descStyle.Render(currentLine))
+					currentLine = "  │ " + word + " "
+				} else {
+					currentLine += word + " "
+				}
+			}
+			if currentLine != "  │ " {
 				lines = append(lines, descStyle.Render(currentLine))
-				currentLine = "  │ " + word + " "
-			} else {
-				currentLine += word + " "
 			}
-		}
-		if currentLine != "  │ " {
-			lines = append(lines, descStyle.Render(currentLine))
-		}
-		lines = append(lines, sectionHeader.Render("  └─────────────────────┘"))
-		lines = append(lines, "")
-
-		// ═══════════ USAGE ═══════════
-		if item.Usage != "" {
-			lines = append(lines, sectionHeader.Render("  ┌─── USAGE ───┐"))
-			usageStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color(colors.primary)).
-				Italic(true)
-			lines = append(lines, fmt.Sprintf("  │ %s", usageStyle.Render(item.Usage)))
-			lines = append(lines, sectionHeader.Render("  └──────────────┘"))
+			lines = append(lines, sectionHeader.Render("  └─────────────────────┘"))
 			lines = append(lines, "")
-		}
 
-		// ═══════════ EXAMPLE ═══════════
-		if item.Example != "" {
-			lines = append(lines, sectionHeader.Render("  ┌─── EXAMPLE ───┐"))
+			// ═══════════ NOTES ═══════════
+			if note := m.notes[item.Cmd]; note != "" {
+				lines = append(lines, sectionHeader.Render("  ┌─── NOTES ───┐"))
+				for _, l := range markdownLines(note, width-8) {
+					lines = append(lines, "  │ "+l)
+				}
+				lines = append(lines, sectionHeader.Render("  └──────────────┘"))
+				lines = append(lines, "")
+			}
 
-			exampleBox := lipgloss.NewStyle().
-				Background(lipgloss.Color(colors.bgDark)).
-				Foreground(lipgloss.Color(colors.success)).
-				Padding(0, 1)
+			// ═══════════ USAGE ═══════════
+			if usage := item.UsageText(m.locale); usage != "" {
+				lines = append(lines, sectionHeader.Render("  ┌─── USAGE ───┐"))
+				usageStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.primary)).
+					Italic(true)
+				lines = append(lines, fmt.Sprintf("  │ %s", usageStyle.Render(usage)))
+				lines = append(lines, sectionHeader.Render("  └──────────────┘"))
+				lines = append(lines, "")
+			}
 
-			lines = append(lines, fmt.Sprintf("  │ $ %s", exampleBox.Render(item.Example)))
-			lines = append(lines, sectionHeader.Render("  └────────────────┘"))
-			lines = append(lines, "")
-		}
+			// ═══════════ EXAMPLE ═══════════
+			if item.Example != "" {
+				lines = append(lines, sectionHeader.Render("  ┌─── EXAMPLE ───┐"))
 
-		// ═══════════ HOTKEY ═══════════
-		if item.Hot != "" {
-			lines = append(lines, sectionHeader.Render("  ┌─── HOTKEY ───┐"))
+				exampleBox := lipgloss.NewStyle().
+					Background(lipgloss.Color(colors.bgDark)).
+					Foreground(lipgloss.Color(colors.success)).
+					Padding(0, 1)
 
-			hotkeyStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color(colors.warning)).
-				Foreground(lipgloss.Color("#000000")).
-				Bold(true).
-				Padding(0, 1)
+				lines = append(lines, fmt.Sprintf("  │ $ %s", exampleBox.Render(item.Example)))
+				lines = append(lines, sectionHeader.Render("  └────────────────┘"))
+				lines = append(lines, "")
+			}
 
-			lines = append(lines, fmt.Sprintf("  │ ⌨️  %s", hotkeyStyle.Render(item.Hot)))
-			lines = append(lines, sectionHeader.Render("  └───────────────┘"))
-			lines = append(lines, "")
-		}
+			// ═══════════ HOTKEY ═══════════
+			if item.Hot != "" {
+				lines = append(lines, sectionHeader.Render("  ┌─── HOTKEY ───┐"))
 
-		// ═══════════ TAGS ═══════════
-		if len(item.Tags) > 0 {
-			lines = append(lines, sectionHeader.Render("  ┌─── TAGS ───┐"))
+				hotkeyStyle := lipgloss.NewStyle().
+					Background(lipgloss.Color(colors.warning)).
+					Foreground(lipgloss.Color("#000000")).
+					Bold(true).
+					Padding(0, 1)
 
-			var tagLine strings.Builder
-			tagLine.WriteString("  │ ")
-			for i, tag := range item.Tags {
-				tagColors := []string{colors.success, colors.primary, colors.secondary, colors.accent}
-				tagColor := tagColors[i%len(tagColors)]
+				lines = append(lines, fmt.Sprintf("  │ ⌨️  %s", hotkeyStyle.Render(item.Hot)))
+				lines = append(lines, sectionHeader.Render("  └───────────────┘"))
+				lines = append(lines, "")
+			}
 
-				tagStyle := lipgloss.NewStyle().
-					Background(lipgloss.Color(colors.bgDark)).
-					Foreground(lipgloss.Color(tagColor)).
-					Padding(0, 1)
-				tagLine.WriteString(tagStyle.Render("#"+tag) + " ")
+			// ═══════════ TAGS ═══════════
+			if len(item.Tags) > 0 {
+				lines = append(lines, sectionHeader.Render("  ┌─── TAGS ───┐"))
+
+				var tagLine strings.Builder
+				tagLine.WriteString("  │ ")
+				for i, tag := range item.Tags {
+					tagColors := []string{colors.success, colors.primary, colors.secondary, colors.accent}
+					tagColor := tagColors[i%len(tagColors)]
+
+					tagStyle := lipgloss.NewStyle().
+						Background(lipgloss.Color(colors.bgDark)).
+						Foreground(lipgloss.Color(tagColor)).
+						Padding(0, 1)
+					tagLine.WriteString(tagStyle.Render("#"+tag) + " ")
+				}
+				lines = append(lines, tagLine.String())
+				lines = append(lines, sectionHeader.Render("  └─────────────┘"))
+				lines = append(lines, "")
 			}
-			lines = append(lines, tagLine.String())
-			lines = append(lines, sectionHeader.Render("  └─────────────┘"))
-			lines = append(lines, "")
-		}
 
-		// ═══════════ DANGER WARNING ═══════════
-		if item.Danger {
-			warningStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("#4A0000")).
-				Foreground(lipgloss.Color(colors.error)).
-				Bold(true).
-				Padding(0, 1)
+			// ═══════════ PLATFORMS ═══════════
+			if len(item.Platforms) > 0 {
+				lines = append(lines, sectionHeader.Render("  ┌─── PLATFORMS ───┐"))
+				platStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary))
+				line := "  │ " + platStyle.Render(strings.Join(item.Platforms, ", "))
+				if reason := item.unavailableReason(currentOS(), m.pwsh); reason != "" {
+					line += lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning)).
+						Render("  ⊘ Not available here: " + reason)
+				}
+				lines = append(lines, line)
+				lines = append(lines, sectionHeader.Render("  └──────────────────┘"))
+				lines = append(lines, "")
+			}
 
-			lines = append(lines, "")
-			lines = append(lines, "  "+warningStyle.Render("⚠️  CAUTION: Admin/Elevated privileges required"))
-		}
+			// ═══════════ RISK & PRIVILEGE ═══════════
+			if risk := m.riskLines(item); len(risk) > 0 {
+				lines = append(lines, sectionHeader.Render("  ┌─── RISK ───┐"))
+				lines = append(lines, risk...)
+				lines = append(lines, sectionHeader.Render("  └─────────────┘"))
+				lines = append(lines, "")
+			}
 
-		// ═══════════ VERSION INFO ═══════════
-		if item.Since != "" {
-			versionStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color(colors.textMuted)).
-				Italic(true)
-			lines = append(lines, "")
-			lines = append(lines, versionStyle.Render(fmt.Sprintf("  📅 Added in %s", item.Since)))
-		}
+			// ═══════════ DEFINITIONS ═══════════
+			name := commandName(item.Cmd)
+			if defs := m.profile.definitionsOf(name); len(defs) > 0 {
+				lines = append(lines, sectionHeader.Render("  ┌─── DEFINED IN ───┐"))
+				locStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+				for _, d := range defs {
+					what := "function"
+					if d.Kind == defAlias {
+						what = "alias → " + d.Target
+					}
+					lines = append(lines, locStyle.Render(fmt.Sprintf("  │ %s  %s", d.Location(), what)))
+				}
+				lines = append(lines, sectionHeader.Render("  └───────────────────┘"))
+				lines = append(lines, "")
+			}
+			if aliases := m.profile.aliasesOf(name); len(aliases) > 0 {
+				var names []string
+				for _, a := range aliases {
+					names = append(names, a.Name)
+				}
+				lines = append(lines, sectionHeader.Render("  ┌─── ALIASES ───┐"))
+				lines = append(lines, fmt.Sprintf("  │ %s", lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.secondary)).
+					Render(strings.Join(names, ", "))))
+				lines = append(lines, sectionHeader.Render("  └────────────────┘"))
+				lines = append(lines, "")
+			}
+			if c, ok := m.profile.conflictOf(name); ok {
+				lines = append(lines, lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.warning)).
+					Bold(true).
+					Render("  ⚔️  Conflict: "+c.Reason()))
+				lines = append(lines, "")
+			}
 
-		// ═══════════ COPY BUTTON ═══════════
-		lines = append(lines, "")
-		lines = append(lines, "")
+			// ═══════════ CUSTOM ENTRY ═══════════
+			if item.Source == sourceUser {
+				customStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.secondary)).
+					Italic(true)
+				lines = append(lines, "")
+				lines = append(lines, customStyle.Render("  ✎ Custom entry (e: edit, D: delete)"))
+			} else if item.Source == sourceTeam {
+				teamStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.secondary)).
+					Italic(true)
+				note := "  ⚑ From the team catalog"
+				if m.team.Name != "" {
+					note += " " + m.team.Name
+				}
+				if m.team.Offline {
+					note += " (offline cache)"
+				}
+				lines = append(lines, "")
+				lines = append(lines, teamStyle.Render(note+" • e: override locally"))
+			} else if name, ok := strings.CutPrefix(item.Source, sourcePluginPrefix); ok {
+				pluginStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.secondary)).
+					Italic(true)
+				lines = append(lines, "")
+				lines = append(lines, pluginStyle.Render("  ⚙ Generated by "+providerPrefix+name+" (P: refresh)"))
+			}
 
-		var btn string
-		if m.copied {
-			btnStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("#064E3B")).
-				Foreground(lipgloss.Color(colors.success)).
-				Bold(true).
-				Padding(0, 3)
-			btn = btnStyle.Render("  ✓ Copied to Clipboard!  ")
-		} else if m.hoverBtn == "copy" {
-			// Animated hover state
-			phase := m.frame % len(pulse)
-			pulseChar := pulse[phase]
+			// ═══════════ HISTORY USAGE ═══════════
+			if n := m.history.typedCount(item); n > 0 {
+				historyStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.warning))
+				lines = append(lines, "")
+				lines = append(lines, historyStyle.Render(fmt.Sprintf("  📈 Typed %d times in shell history", n)))
+			}
 
-			btnStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color(grad[0])).
-				Foreground(lipgloss.Color("#000000")).
-				Bold(true).
-				Padding(0, 3)
-			btn = btnStyle.Render(fmt.Sprintf(" %s Click to Copy %s ", pulseChar, pulseChar))
-		} else {
-			btnStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color(colors.surfaceHL)).
-				Foreground(lipgloss.Color(grad[0])).
-				Padding(0, 3)
-			btn = btnStyle.Render("  📋 Press Enter to Copy  ")
-		}
-		lines = append(lines, "  "+btn)
+			// ═══════════ VERSION INFO ═══════════
+			if item.Since != "" {
+				versionStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color(colors.textMuted)).
+					Italic(true)
+				lines = append(lines, "")
+				lines = append(lines, versionStyle.Render(fmt.Sprintf("  📅 Added in %s", item.Since)))
+			}
 
-		// Tips
-		lines = append(lines, "")
-		tipStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colors.textMuted)).
-			Italic(true)
-		lines = append(lines, tipStyle.Render("  💡 Double-click or Enter to copy"))
-		lines = append(lines, tipStyle.Render("  🖱️  Scroll to navigate"))
+			// ═══════════ COPY BUTTON ═══════════
+			lines = append(lines, "")
+			lines = append(lines, "")
+
+			var btn string
+			if m.copied {
+				btnStyle := lipgloss.NewStyle().
+					Background(lipgloss.Color("#064E3B")).
+					Foreground(lipgloss.Color(colors.success)).
+					Bold(true).
+					Padding(0, 3)
+				btn = btnStyle.Render("  ✓ Copied to Clipboard!  ")
+			} else if m.hoverBtn == "copy" {
+				// Animated hover state
+				phase := m.frame % len(pulse)
+				pulseChar := pulse[phase]
+
+				btnStyle := lipgloss.NewStyle().
+					Background(lipgloss.Color(grad[0])).
+					Foreground(lipgloss.Color("#000000")).
+					Bold(true).
+					Padding(0, 3)
+				btn = btnStyle.Render(fmt.Sprintf(" %s Click to Copy %s ", pulseChar, pulseChar))
+			} else {
+				btnStyle := lipgloss.NewStyle().
+					Background(lipgloss.Color(colors.surfaceHL)).
+					Foreground(lipgloss.Color(grad[0])).
+					Padding(0, 3)
+				btn = btnStyle.Render("  📋 Press Enter to Copy  ")
+			}
+			buttonLine = len(lines)
+			lines = append(lines, "  "+btn)
+
+			// Tips
+			lines = append(lines, "")
+			tipStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(colors.textMuted)).
+				Italic(true)
+			lines = append(lines, tipStyle.Render("  💡 Double-click or Enter to copy"))
+			lines = append(lines, tipStyle.Render("  🖱️  Scroll to navigate"))
+			lines = append(lines, tipStyle.Render("  📜 s: show source"))
+		}
 
 	} else {
 		// No command selected
@@ -2144,8 +2901,48 @@ func (m *Model) viewDetail() string {
 		lines = append(lines, emptyStyle.Render("  to view detailed information"))
 	}
 
+	return lines, buttonLine
+}
+
+// detailOffset clamps detailScroll so the last page of the detail pane
+// stays filled.
+func (m *Model) detailOffset(total, visible int) int {
+	return max(0, min(m.detailScroll, total-visible))
+}
+
+func (m *Model) viewDetail() string {
+	cat := m.categories[m.catIndex]
+	grad := getGradient(m.gradientOf(cat))
+	height := m.layout.DetailH
+	width := m.layout.DetailW
+
+	var s strings.Builder
+
+	// Animated header
+	sparkle := sparkles[m.frame%len(sparkles)]
+	title := fmt.Sprintf(" %s Command Details %s ", sparkle, sparkle)
+
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(grad[len(grad)-1])).
+		Bold(true)
+
+	headerLen := lipgloss.Width(title)
+	padLen := width - headerLen - 4
+	if padLen < 0 {
+		padLen = 0
+	}
+
+	s.WriteString(gradientStr("╭─", m.gradientOf(cat)))
+	s.WriteString(headerStyle.Render(title))
+	s.WriteString(gradientStr(strings.Repeat("─", padLen)+"╮", m.gradientOf(cat)))
+	s.WriteString("\n")
+
+	// Content lines
+	lines, _ := m.detailLines(width)
+
 	// Render content with animated borders
 	visible := height - 3
+	lines = lines[m.detailOffset(len(lines), visible):]
 	for i := 0; i < visible; i++ {
 		borderT := float64(i) / float64(visible)
 		borderC := lerpColor(grad, borderT)
@@ -2175,15 +2972,18 @@ func (m *Model) viewDetail() string {
 
 	// Footer
 	footerSparkle := sparkles[(m.frame+4)%len(sparkles)]
-	s.WriteString(gradientStr("╰"+strings.Repeat("─", width-4), cat.Gradient))
+	s.WriteString(gradientStr("╰"+strings.Repeat("─", width-4), m.gradientOf(cat)))
 	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(grad[0])).Render(footerSparkle))
-	s.WriteString(gradientStr("─╯", cat.Gradient))
+	s.WriteString(gradientStr("─╯", m.gradientOf(cat)))
 
 	return s.String()
 }
 
 
 func (m *Model) viewStatus() string {
+	if m.cmdline != nil {
+		return m.viewCommandLine()
+	}
 	cat := m.categories[m.catIndex]
 	// grad := getGradient(cat.Gradient) // Unused now
 
@@ -2259,7 +3059,7 @@ func (m *Model) viewStatus() string {
 	}
 
 	catInfo := fmt.Sprintf("%s %s %d/%d %s",
-		gradientStr(spin, cat.Gradient),
+		gradientStr(spin, m.gradientOf(cat)),
 		cat.Icon,
 		m.catIndex+1,
 		len(m.categories),
@@ -2336,6 +3136,10 @@ func (m Model) viewHelp() string {
 				{"1-9", "Quick jump to category"},
 				{"Tab", "Next category"},
 				{"Shift+Tab", "Previous category"},
+				{"@", "Jump to category by name"},
+				{"b", "Toggle the category tree sidebar"},
+				{"z", "Fold/unfold subcategories"},
+				{"{ / }", "Move category up / down"},
 			},
 		},
 		{
@@ -2345,13 +3149,40 @@ func (m Model) viewHelp() string {
 				{"/ or Ctrl+F", "Open search"},
 				{"Esc", "Close search / Clear"},
 				{"Enter", "Confirm search"},
+				{":", "Command line (Tab completes, ↑↓ history)"},
 			},
 		},
 		{
 			title: "📋 ACTIONS",
 			grad:  "matrix",
 			binds: []struct{ key, desc string }{
-				{"Enter / Space", "Copy command to clipboard"},
+				{"Enter", "Copy command to clipboard"},
+				{"Enter on gco/gb", "Pick a branch from the repository"},
+				{"m / right-click", "Item menu"},
+				{"y / Y / u", "Copy command / example / usage"},
+				{"r", "Run selected command"},
+				{"p", "Pin / unpin (pinned list first)"},
+				{"o", "Open definition in $EDITOR"},
+				{"Space", "Select for the composer"},
+				{"c", "Open the pipeline composer"},
+				{"W", "Saved workflows"},
+				{"#", "Tag browser (Esc clears the tag filter)"},
+				{"O", "Show commands for all platforms"},
+				{"! / ^", "Filter by risk level / privilege"},
+				{"R", "Refresh the team catalog"},
+				{"P", "Rerun plugin providers"},
+				{"T", "Learn mode (spaced repetition)"},
+				{"L", "Switch description language"},
+				{"s", "Toggle profile source in details"},
+				{"J / K", "Scroll details"},
+				{"f", "Toggle full-screen details"},
+				{"< / >", "Shrink / grow the list pane"},
+				{"n", "Add a personal command"},
+				{"e", "Edit selected command"},
+				{"N", "Edit the note on the command"},
+				{"D / Del", "Delete selected command"},
+				{"S", "Suggestions from shell history"},
+				{"C", "Alias/definition conflicts"},
 				{"? / F1", "Toggle this help"},
 				{"q / Ctrl+C", "Quit application"},
 			},
@@ -2398,6 +3229,7 @@ func (m Model) viewHelp() string {
 	mouseBinds := []struct{ action, desc string }{
 		{"Click", "Select item or category"},
 		{"Double-click", "Copy command instantly"},
+		{"Shift+Click", "Select for the composer"},
 		{"Hover", "Highlight interactive elements"},
 		{"Scroll wheel", "Navigate list up/down"},
 	}
@@ -2490,13 +3322,44 @@ func min(a, b int) int {
 // ══════════════════════════════════════════════════════════════════
 
 func main() {
-	p := tea.NewProgram(
-		newModel(),
+	if len(os.Args) > 1 {
+		var run func([]string) error
+		switch os.Args[1] {
+		case "serve":
+			run = runServe
+		case "schema":
+			run = runSchema
+		case "screenshot":
+			run = runScreenshot
+		case "config":
+			run = runConfig
+		}
+		if run != nil {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	var sess termshot.Options
+	fs := flag.NewFlagSet("features", flag.ExitOnError)
+	fs.StringVar(&configOverride, "config", "", "config file (default "+statePath(configFile)+")")
+	sess.Register(fs)
+	fs.Parse(os.Args[1:])
+	if configOverride != "" {
+		if _, err := os.Stat(configOverride); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	err := runSession(newModel(), sess,
 		tea.WithAltScreen(),
 		tea.WithMouseAllMotion(),
 	)
-
-	if _, err := p.Run(); err != nil {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,350 @@
+// menu.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         ITEM ACTIONS & CONTEXT MENU
+// ══════════════════════════════════════════════════════════════════
+
+// Item actions, shared by the context menu and their key bindings.
+const (
+	actCopy    = "copy"
+	actExample = "example"
+	actUsage   = "usage"
+	actRun     = "run"
+	actPin     = "pin"
+	actSource  = "source"
+	actEdit    = "edit"
+	actNote    = "note"
+)
+
+type menuAction struct {
+	ID    string
+	Key   string
+	Label string
+}
+
+var menuActions = []menuAction{
+	{actCopy, "y", "Copy command"},
+	{actExample, "Y", "Copy example"},
+	{actUsage, "u", "Copy usage"},
+	{actRun, "r", "Run"},
+	{actPin, "p", "Pin / unpin"},
+	{actSource, "s", "Show source"},
+	{actEdit, "o", "Open in editor"},
+	{actNote, "N", "Edit note"},
+}
+
+// contextMenu is an open menu for one catalog item at screen position X, Y.
+type contextMenu struct {
+	Item Command
+	X, Y int
+	Sel  int
+}
+
+const menuW = 28
+
+// openMenu opens the context menu for the selected item at x, y, clamped
+// to the screen.
+func (m *Model) openMenu(x, y int) {
+	if m.itemIndex >= len(m.filtered) {
+		return
+	}
+	h := len(menuActions) + 2
+	m.menu = &contextMenu{
+		Item: m.filtered[m.itemIndex],
+		X:    max(0, min(x, m.width-menuW)),
+		Y:    max(0, min(y, m.height-h)),
+	}
+}
+
+func (m Model) handleMenuKey(key string) (tea.Model, tea.Cmd) {
+	menu := m.menu
+	switch key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "m", "q":
+		m.menu = nil
+	case "up", "k":
+		menu.Sel = (menu.Sel - 1 + len(menuActions)) % len(menuActions)
+	case "down", "j", "tab":
+		menu.Sel = (menu.Sel + 1) % len(menuActions)
+	case "enter", " ":
+		m.menu = nil
+		return m, m.itemAction(menuActions[menu.Sel].ID, menu.Item)
+	default:
+		for _, a := range menuActions {
+			if a.Key == key {
+				m.menu = nil
+				return m, m.itemAction(a.ID, menu.Item)
+			}
+		}
+	}
+	return m, nil
+}
+
+// menuClick handles a left click while the menu is open: a row runs its
+// action, anywhere else closes the menu.
+func (m Model) menuClick(x, y int) (tea.Model, tea.Cmd) {
+	menu := m.menu
+	m.menu = nil
+	row := y - menu.Y - 1
+	if x >= menu.X && x < menu.X+menuW && row >= 0 && row < len(menuActions) {
+		return m, m.itemAction(menuActions[row].ID, menu.Item)
+	}
+	return m, nil
+}
+
+// menuHover highlights the row under the mouse.
+func (m *Model) menuHover(x, y int) {
+	row := y - m.menu.Y - 1
+	if x >= m.menu.X && x < m.menu.X+menuW && row >= 0 && row < len(menuActions) {
+		m.menu.Sel = row
+	}
+}
+
+// itemAction performs an item action on c.
+func (m *Model) itemAction(id string, c Command) tea.Cmd {
+	switch id {
+	case actCopy:
+		m.copyCommand(c)
+	case actExample:
+		if c.Example == "" {
+			m.showToast("No example for "+c.Cmd, "warning")
+			return nil
+		}
+		m.copyText(c.Example, c.Cmd)
+	case actUsage:
+		usage := c.UsageText(m.locale)
+		if usage == "" {
+			m.showToast("No usage for "+c.Cmd, "warning")
+			return nil
+		}
+		m.copyText(usage, c.Cmd)
+	case actRun:
+		return m.confirmRisky(c.Cmd, []Command{c}, func(m *Model) tea.Cmd {
+			if len(placeholders(c.Cmd)) > 0 {
+				m.openPlaceholderForm(c)
+				m.form.Kind = formPlaceholderRun
+				return nil
+			}
+			return runLine(c.Cmd)
+		})
+	case actPin:
+		m.togglePin(c.Cmd)
+	case actSource:
+		m.detailTab = 1
+		m.detailScroll = 0
+	case actEdit:
+		return m.openInEditor(c)
+	case actNote:
+		return m.openNoteEditor(c)
+	}
+	return nil
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         PINS
+// ══════════════════════════════════════════════════════════════════
+
+const pinsFile = "pins.json"
+
+type pinStore struct {
+	Pins []string `json:"pins"`
+}
+
+func loadPins() (map[string]bool, error) {
+	var ps pinStore
+	err := loadJSON(statePath(pinsFile), &ps)
+	pins := make(map[string]bool, len(ps.Pins))
+	for _, p := range ps.Pins {
+		pins[p] = true
+	}
+	return pins, err
+}
+
+func savePins(pins map[string]bool) error {
+	ps := pinStore{Pins: make([]string, 0, len(pins))}
+	for p := range pins {
+		ps.Pins = append(ps.Pins, p)
+	}
+	sort.Strings(ps.Pins)
+	return saveJSON(statePath(pinsFile), ps)
+}
+
+// togglePin pins or unpins cmd; pinned commands list first.
+func (m *Model) togglePin(cmd string) {
+	pins := make(map[string]bool, len(m.pins)+1)
+	for k := range m.pins {
+		pins[k] = true
+	}
+	msg := "Pinned: " + cmd
+	if pins[cmd] {
+		delete(pins, cmd)
+		msg = "Unpinned: " + cmd
+	} else {
+		pins[cmd] = true
+	}
+	if err := savePins(pins); err != nil {
+		m.showToast(fmt.Sprintf("Save failed: %v", err), "error")
+		return
+	}
+	m.pins = pins
+
+	cur := ""
+	if m.itemIndex < len(m.filtered) {
+		cur = m.filtered[m.itemIndex].Cmd
+	}
+	m.updateFiltered()
+	for i, c := range m.filtered {
+		if c.Cmd == cur {
+			m.itemIndex = i
+		}
+	}
+	m.adjustScroll()
+	m.showToast(msg, "info")
+}
+
+// sortPinned moves pinned commands to the front, keeping the order.
+func (m *Model) sortPinned(cmds []Command) []Command {
+	if len(m.pins) == 0 {
+		return cmds
+	}
+	out := make([]Command, len(cmds))
+	copy(out, cmds)
+	sort.SliceStable(out, func(i, j int) bool {
+		return m.pins[out[i].Cmd] && !m.pins[out[j].Cmd]
+	})
+	return out
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         OPEN IN EDITOR
+// ══════════════════════════════════════════════════════════════════
+
+// editorCommand builds the command opening file at line in $VISUAL or
+// $EDITOR, with the line syntax the editor understands.
+func editorCommand(file string, line int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	args := strings.Fields(editor)
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(args[0])), ".exe")
+
+	switch name {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "-g", file+":"+strconv.Itoa(line))
+	case "subl", "zed":
+		args = append(args, file+":"+strconv.Itoa(line))
+	case "vi", "vim", "nvim", "nano", "emacs", "micro", "hx", "kak":
+		args = append(args, "+"+strconv.Itoa(line), file)
+	default:
+		args = append(args, file)
+	}
+	return exec.Command(args[0], args[1:]...)
+}
+
+// openInEditor opens the profile definition of c, or the personal catalog
+// for custom entries.
+func (m *Model) openInEditor(c Command) tea.Cmd {
+	file, line := "", 1
+	if defs := m.profile.definitionsOf(commandName(c.Cmd)); len(defs) > 0 {
+		// The last definition wins in PowerShell
+		def := defs[len(defs)-1]
+		file, line = def.File, def.Line
+	} else if c.Source == sourceUser {
+		file, line = statePath(userCatalogFile), catalogLine(statePath(userCatalogFile), c.Cmd)
+	}
+	if file == "" {
+		m.showToast("No definition found for "+c.Cmd, "warning")
+		return nil
+	}
+	return tea.ExecProcess(editorCommand(file, line), func(err error) tea.Msg {
+		return execDoneMsg{Line: "edit " + filepath.Base(file), Err: err}
+	})
+}
+
+// catalogLine finds the line of cmd's entry in a JSON catalog file.
+func catalogLine(path, cmd string) int {
+	lines, err := readLines(path)
+	if err != nil {
+		return 1
+	}
+	quoted, _ := json.Marshal(cmd)
+	for i, l := range lines {
+		if strings.Contains(l, `"cmd": `+string(quoted)) {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         MENU RENDERING
+// ══════════════════════════════════════════════════════════════════
+
+func (m *Model) viewMenu() string {
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent)).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	selStyle := lipgloss.NewStyle().Background(lipgloss.Color(colors.surfaceHL))
+
+	var rows []string
+	for i, a := range menuActions {
+		label := a.Label
+		if a.ID == actPin && m.pins[m.menu.Item.Cmd] {
+			label = "Unpin"
+		}
+		row := fmt.Sprintf(" %s  %s", keyStyle.Render(a.Key), labelStyle.Render(label))
+		row = lipgloss.NewStyle().Width(menuW - 2).Render(row)
+		if i == m.menu.Sel {
+			row = selStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	return lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.secondary)).
+		Render(strings.Join(rows, "\n"))
+}
+
+// overlay draws box over base with its top-left corner at x, y.
+func overlay(base, box string, x, y int) string {
+	lines := strings.Split(base, "\n")
+	for i, bl := range strings.Split(box, "\n") {
+		row := y + i
+		if row < 0 || row >= len(lines) {
+			continue
+		}
+		line := lines[row]
+		if w := ansi.StringWidth(line); w < x {
+			line += strings.Repeat(" ", x-w)
+		}
+		left := ansi.Truncate(line, x, "")
+		right := ansi.TruncateLeft(line, x+ansi.StringWidth(bl), "")
+		lines[row] = left + bl + "\x1b[0m" + right
+	}
+	return strings.Join(lines, "\n")
+}
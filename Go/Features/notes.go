@@ -0,0 +1,250 @@
+// notes.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         NOTES
+// ══════════════════════════════════════════════════════════════════
+//
+// Personal Markdown notes attached to commands, stored in notes.json keyed
+// by Command.Cmd. They show in the detail pane and match in search.
+
+const notesFile = "notes.json"
+
+type noteStore struct {
+	Notes map[string]string `json:"notes"`
+}
+
+func loadNotes() (map[string]string, error) {
+	var ns noteStore
+	err := loadJSON(statePath(notesFile), &ns)
+	if ns.Notes == nil {
+		ns.Notes = make(map[string]string)
+	}
+	return ns.Notes, err
+}
+
+func saveNotes(notes map[string]string) error {
+	return saveJSON(statePath(notesFile), noteStore{Notes: notes})
+}
+
+// noteEditor is the overlay editing the note of one command.
+type noteEditor struct {
+	Cmd  string
+	Area textarea.Model
+}
+
+// openNoteEditor starts editing the note of c.
+func (m *Model) openNoteEditor(c Command) tea.Cmd {
+	ta := textarea.New()
+	ta.Placeholder = "Markdown: # heading, - item, **bold**, `code`"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 4000
+	ta.SetWidth(max(20, min(72, m.width-12)))
+	ta.SetHeight(max(4, min(14, m.height-14)))
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle().Background(lipgloss.Color(colors.surfaceHL))
+	ta.FocusedStyle.Text = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+	ta.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent))
+	ta.SetValue(m.notes[c.Cmd])
+	m.note = &noteEditor{Cmd: c.Cmd, Area: ta}
+	return m.note.Area.Focus()
+}
+
+// handleNoteKey routes keys to the note editor.
+func (m Model) handleNoteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.note = nil
+		return m, nil
+	case "ctrl+s":
+		m.saveNote()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.note.Area, cmd = m.note.Area.Update(msg)
+	return m, cmd
+}
+
+// saveNote stores the edited note; an empty note is removed.
+func (m *Model) saveNote() {
+	cmd, text := m.note.Cmd, strings.TrimSpace(m.note.Area.Value())
+	notes := make(map[string]string, len(m.notes)+1)
+	for k, v := range m.notes {
+		notes[k] = v
+	}
+	msg := "Note saved for " + cmd
+	if text == "" {
+		delete(notes, cmd)
+		msg = "Note removed from " + cmd
+	} else {
+		notes[cmd] = text
+	}
+	if err := saveNotes(notes); err != nil {
+		m.showToast(fmt.Sprintf("Save failed: %v", err), "error")
+		return
+	}
+	m.notes = notes
+	m.note = nil
+	if m.searchInput.Value() != "" {
+		m.updateFiltered()
+		m.itemIndex = min(m.itemIndex, max(0, len(m.filtered)-1))
+		m.adjustScroll()
+	}
+	m.showToast(msg, "success")
+}
+
+func (m Model) viewNoteEditor() string {
+	var b strings.Builder
+	b.WriteString(gradientStr(fmt.Sprintf("✦ Note for %s ✦", m.note.Cmd), "aurora") + "\n\n")
+	b.WriteString(m.note.Area.View() + "\n")
+
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.textMuted)).
+		Italic(true).
+		Render("Ctrl+S save • Esc cancel • an empty note is removed")
+	b.WriteString("\n" + hint)
+
+	box := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.secondary)).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lipgloss.Place(m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		box,
+		lipgloss.WithWhitespaceBackground(lipgloss.Color("#000000")),
+	)
+}
+
+// ── Markdown ─────────────────────────────────────────────────────
+
+// markdownLines renders a note for the detail pane: headings, lists,
+// quotes, fenced code and inline **bold**, *italic* and `code`.
+func markdownLines(text string, width int) []string {
+	width = max(10, width)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	headStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+	quoteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim)).Italic(true)
+	codeStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Foreground(lipgloss.Color(colors.success))
+
+	var lines []string
+	wrap := func(prefix, indent, s string, style lipgloss.Style) {
+		body := lipgloss.NewStyle().Width(width - lipgloss.Width(prefix)).Render(inlineMarkdown(s, style))
+		for i, l := range strings.Split(body, "\n") {
+			if i == 0 {
+				lines = append(lines, prefix+l)
+			} else {
+				lines = append(lines, indent+l)
+			}
+		}
+	}
+
+	inFence := false
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(raw)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			lines = append(lines, codeStyle.Render(truncateRunes(raw, width)))
+			continue
+		}
+		switch {
+		case trimmed == "":
+			if len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+		case strings.HasPrefix(trimmed, "#"):
+			wrap("", "", strings.TrimSpace(strings.TrimLeft(trimmed, "#")), headStyle)
+		case strings.HasPrefix(trimmed, "> "):
+			wrap("▎ ", "▎ ", trimmed[2:], quoteStyle)
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			wrap("• ", "  ", trimmed[2:], textStyle)
+		default:
+			wrap("", "", trimmed, textStyle)
+		}
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// inlineMarkdown styles **bold**, *italic*/_italic_ and `code` spans on
+// top of base.
+func inlineMarkdown(s string, base lipgloss.Style) string {
+	codeStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Foreground(lipgloss.Color(colors.success))
+
+	var b, plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			b.WriteString(base.Render(plain.String()))
+			plain.Reset()
+		}
+	}
+	for i := 0; i < len(s); {
+		var marker string
+		var style lipgloss.Style
+		switch {
+		case s[i] == '`':
+			marker, style = "`", codeStyle
+		case strings.HasPrefix(s[i:], "**"):
+			marker, style = "**", base.Bold(true)
+		case (s[i] == '*' || s[i] == '_') && (i == 0 || s[i-1] == ' '):
+			marker, style = s[i:i+1], base.Italic(true)
+		}
+		if marker != "" {
+			rest := s[i+len(marker):]
+			end := strings.Index(rest, marker)
+			if marker == "*" || marker == "_" {
+				end = closingMarker(rest, marker[0])
+			}
+			if end > 0 && rest[0] != ' ' {
+				flush()
+				b.WriteString(style.Render(rest[:end]))
+				i += len(marker) + end + len(marker)
+				continue
+			}
+		}
+		plain.WriteByte(s[i])
+		i++
+	}
+	flush()
+	return b.String()
+}
+
+// closingMarker returns the index of the marker that closes an italic
+// span in rest: one that follows a non-space and is not followed by a
+// word character, so "ls *.txt *.md" and "snake_case_name" stay plain.
+// It returns -1 when there is none.
+func closingMarker(rest string, marker byte) int {
+	for i := 1; i < len(rest); i++ {
+		if rest[i] == marker && rest[i-1] != ' ' && (i+1 == len(rest) || !isWordByte(rest[i+1])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isWordByte reports whether b is part of a word: a letter, digit, _ or
+// any byte of a non-ASCII character.
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 ||
+		'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
@@ -0,0 +1,50 @@
+// notes_test.go
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestInlineMarkdown(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	base := lipgloss.NewStyle()
+	italic := func(s string) string { return base.Italic(true).Render(s) }
+	bold := func(s string) string { return base.Bold(true).Render(s) }
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"*very* careful", italic("very") + base.Render(" careful")},
+		{"an _emphasis_.", base.Render("an ") + italic("emphasis") + base.Render(".")},
+		{"**bold** move", bold("bold") + base.Render(" move")},
+		{"ls *.txt *.md", base.Render("ls *.txt *.md")},
+		{"rm *_old", base.Render("rm *_old")},
+		{"my_var_name", base.Render("my_var_name")},
+		{"_a_b c_", italic("a_b c")},
+		{"* not a list*", base.Render("* not a list*")},
+		{"*open", base.Render("*open")},
+	}
+	for _, tt := range tests {
+		if got := inlineMarkdown(tt.in, base); got != tt.want {
+			t.Errorf("inlineMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportMarkdownQuotesNotes(t *testing.T) {
+	m := Model{
+		categories: []Category{{ID: "git", Name: "Git"}},
+		filtered:   []Command{{Cmd: "gs", Desc: "Status"}},
+		notes:      map[string]string{"gs": "# Heads up\r\n\r\n- use `-s`\n"},
+	}
+	want := "# Git\n\n## `gs`\n\nStatus\n\n> # Heads up\n>\n> - use `-s`\n"
+	if got := m.exportMarkdown(m.categories[0]); got != want {
+		t.Errorf("exportMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
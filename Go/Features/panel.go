@@ -0,0 +1,138 @@
+// panel.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         SCREENS & PANELS
+// ══════════════════════════════════════════════════════════════════
+
+// Alternative screens shown in place of the list/detail content.
+const (
+	screenCatalog   = ""
+	screenSuggest   = "suggest"
+	screenConflicts = "conflicts"
+	screenComposer  = "composer"
+	screenWorkflows = "workflows"
+	screenRunner    = "runner"
+	screenLearn     = "learn"
+	screenBranches  = "branches"
+	screenTags      = "tags"
+)
+
+// setScreen switches to screen s (or back to the catalog when already on
+// it) and resets the panel cursor.
+func (m *Model) setScreen(s string) {
+	if m.screen == s {
+		s = screenCatalog
+	}
+	m.screen = s
+	m.panelIndex = 0
+	m.panelScroll = 0
+}
+
+// movePanel moves the panel cursor by delta within n rows.
+func (m *Model) movePanel(delta, n int) {
+	m.panelIndex = max(0, min(n-1, m.panelIndex+delta))
+	visible := max(1, m.layout.ContentH-3)
+	if m.panelIndex < m.panelScroll {
+		m.panelScroll = m.panelIndex
+	} else if m.panelIndex >= m.panelScroll+visible {
+		m.panelScroll = m.panelIndex - visible + 1
+	}
+}
+
+// renderPanel draws a full-width bordered list in the style of viewList.
+// rows are pre-rendered lines; sel is highlighted. footer is shown in the
+// bottom border.
+func (m *Model) renderPanel(title, grad string, rows []string, sel int, footer string) string {
+	cols := getGradient(grad)
+	width := m.width - m.layout.Padding*2 - 2 - m.layout.SideW
+	height := m.layout.ContentH
+
+	var s strings.Builder
+
+	cornerAnim := sparkles[m.frame%len(sparkles)]
+	titleStr := fmt.Sprintf(" %s ", title)
+	padLen := max(0, width-lipgloss.Width(titleStr)-4)
+
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(cols[0])).Render(cornerAnim + "─"))
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(cols[0])).Bold(true).Render(titleStr))
+	s.WriteString(gradientStr(strings.Repeat("─", padLen), grad))
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(cols[len(cols)-1])).Render("─" + cornerAnim))
+	s.WriteString("\n")
+
+	visible := height - 3
+	inner := width - 2
+	for i := 0; i < visible; i++ {
+		idx := i + m.panelScroll
+		borderC := lerpColor(cols, float64(i)/float64(max(1, visible)))
+		border := lipgloss.NewStyle().Foreground(lipgloss.Color(borderC)).Render("│")
+		s.WriteString(border)
+
+		line := ""
+		if idx < len(rows) {
+			line = rows[idx]
+		}
+		if lipgloss.Width(line) > inner {
+			line = truncateRunes(stripANSI(line), inner-1) + "…"
+		}
+		style := lipgloss.NewStyle().Width(inner)
+		if idx == sel && idx < len(rows) {
+			style = style.Background(lipgloss.Color(colors.surfaceHL)).Bold(true)
+		}
+		s.WriteString(style.Render(line))
+		s.WriteString(border + "\n")
+	}
+
+	footerStr := fmt.Sprintf(" %s ", footer)
+	footPad := max(0, width-lipgloss.Width(footerStr)-4)
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(cols[len(cols)-1])).Render(cornerAnim + "─"))
+	s.WriteString(gradientStr(strings.Repeat("─", footPad), grad))
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim)).Render(footerStr))
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(cols[0])).Render("─" + cornerAnim))
+
+	return lipgloss.NewStyle().MarginLeft(m.layout.Padding).Render(s.String()) + "\n"
+}
+
+// truncateRunes cuts s to at most n display columns.
+func truncateRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := lipgloss.Width(string(r))
+		if w+rw > n {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	return b.String()
+}
+
+// stripANSI removes SGR escape sequences from s.
+func stripANSI(s string) string {
+	var b strings.Builder
+	inEsc := false
+	for _, r := range s {
+		switch {
+		case r == '\x1b':
+			inEsc = true
+		case inEsc:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEsc = false
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
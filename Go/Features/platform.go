@@ -0,0 +1,143 @@
+// platform.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         PLATFORMS
+// ══════════════════════════════════════════════════════════════════
+//
+// Command.Platforms lists where a command works: "windows", "linux",
+// "macos", and optionally a PowerShell requirement such as "pwsh>=7.2".
+// No OS entry means any OS. Unavailable commands are hidden unless all
+// platforms are shown, in which case they are greyed out.
+
+const pwshPrefix = "pwsh>="
+
+// currentOS returns runtime.GOOS in Platforms terms.
+func currentOS() string {
+	if runtime.GOOS == "darwin" {
+		return "macos"
+	}
+	return runtime.GOOS
+}
+
+// pwshVersionMsg carries the version of the installed PowerShell, "" when
+// none was found.
+type pwshVersionMsg string
+
+// detectPwshCmd asks pwsh (or Windows PowerShell) for its version.
+func detectPwshCmd() tea.Msg {
+	for _, sh := range []string{"pwsh", "powershell"} {
+		p, err := exec.LookPath(sh)
+		if err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		out, err := exec.CommandContext(ctx, p, "-NoLogo", "-NoProfile", "-Command",
+			"$PSVersionTable.PSVersion.ToString()").Output()
+		cancel()
+		if err == nil {
+			return pwshVersionMsg(strings.TrimSpace(string(out)))
+		}
+	}
+	return pwshVersionMsg("")
+}
+
+// unavailableReason explains why c does not work here, or returns "" when
+// it does. pwsh is the detected PowerShell version; requirements are not
+// checked before it is known.
+func (c Command) unavailableReason(goos, pwsh string) string {
+	var oses []string
+	for _, p := range c.Platforms {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if want, ok := strings.CutPrefix(p, pwshPrefix); ok {
+			if pwsh != "" && !versionAtLeast(pwsh, want) {
+				return fmt.Sprintf("needs PowerShell %s+ (have %s)", want, pwsh)
+			}
+			continue
+		}
+		oses = append(oses, p)
+	}
+	if len(oses) == 0 {
+		return ""
+	}
+	for _, name := range oses {
+		if name == goos {
+			return ""
+		}
+	}
+	return strings.Join(oses, "/") + " only"
+}
+
+// versionAtLeast compares dotted version numbers, ignoring suffixes such
+// as "-preview".
+func versionAtLeast(have, want string) bool {
+	h := strings.Split(strings.SplitN(have, "-", 2)[0], ".")
+	w := strings.Split(want, ".")
+	for i := 0; i < len(w); i++ {
+		wn, _ := strconv.Atoi(w[i])
+		hn := 0
+		if i < len(h) {
+			hn, _ = strconv.Atoi(h[i])
+		}
+		if hn != wn {
+			return hn > wn
+		}
+	}
+	return true
+}
+
+// available reports whether c works on this machine.
+func (m *Model) available(c Command) bool {
+	return c.unavailableReason(currentOS(), m.pwsh) == ""
+}
+
+// availableOnly drops the commands that don't work here, unless all
+// platforms are shown.
+func (m *Model) availableOnly(cmds []Command) []Command {
+	if m.showAllOS {
+		return cmds
+	}
+	out := make([]Command, 0, len(cmds))
+	for _, c := range cmds {
+		if m.available(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// toggleAllPlatforms shows or hides commands unavailable here, keeping the
+// selection when it stays visible.
+func (m *Model) toggleAllPlatforms() {
+	m.showAllOS = !m.showAllOS
+	cur := ""
+	if m.itemIndex < len(m.filtered) {
+		cur = m.filtered[m.itemIndex].Cmd
+	}
+	m.updateFiltered()
+	m.itemIndex = 0
+	for i, c := range m.filtered {
+		if c.Cmd == cur {
+			m.itemIndex = i
+		}
+	}
+	m.adjustScroll()
+
+	if m.showAllOS {
+		m.showToast("Showing commands for all platforms", "info")
+	} else {
+		m.showToast("Hiding commands unavailable on "+currentOS(), "info")
+	}
+}
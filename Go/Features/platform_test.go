@@ -0,0 +1,49 @@
+// platform_test.go
+package main
+
+import "testing"
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		have, want string
+		ok         bool
+	}{
+		{"7.4.1", "7.2", true},
+		{"7.2", "7.2", true},
+		{"7.2.0", "7.2", true},
+		{"7.1.5", "7.2", false},
+		{"5.1.19041.1", "7", false},
+		{"7.10", "7.9", true},
+		{"7", "7.0.1", false},
+		{"7.5.0-preview.2", "7.5", true},
+		{"7.4.0-rc.1", "7.5", false},
+	}
+	for _, tt := range tests {
+		if got := versionAtLeast(tt.have, tt.want); got != tt.ok {
+			t.Errorf("versionAtLeast(%q, %q) = %v, want %v", tt.have, tt.want, got, tt.ok)
+		}
+	}
+}
+
+func TestUnavailableReason(t *testing.T) {
+	tests := []struct {
+		name      string
+		platforms []string
+		goos      string
+		pwsh      string
+		want      string
+	}{
+		{"any", nil, "linux", "7.4", ""},
+		{"same os", []string{"Linux"}, "linux", "", ""},
+		{"other os", []string{"windows", "macos"}, "linux", "", "windows/macos only"},
+		{"new enough", []string{"pwsh>=7.2"}, "linux", "7.4.1", ""},
+		{"too old", []string{"pwsh>=7.2"}, "windows", "5.1", "needs PowerShell 7.2+ (have 5.1)"},
+		{"not yet detected", []string{"pwsh>=7.2"}, "windows", "", ""},
+	}
+	for _, tt := range tests {
+		c := Command{Cmd: "x", Platforms: tt.platforms}
+		if got := c.unavailableReason(tt.goos, tt.pwsh); got != tt.want {
+			t.Errorf("%s: unavailableReason() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
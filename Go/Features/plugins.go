@@ -0,0 +1,248 @@
+// plugins.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         PLUGIN PROVIDERS
+// ══════════════════════════════════════════════════════════════════
+//
+// A provider is an executable named features-provider-<name>, found in
+// the plugins dir of the state dir or on PATH. It is run in the current
+// directory and prints its entries as JSON on stdout in the catalog format
+// (see schema.go), usually grouped by category:
+//
+//	{"schemaVersion": 2, "categories": [{"id": "make", "name": "Make", "icon": "🔨",
+//	  "commands": [{"cmd": "make build", "desc": "Build the project"}]}]}
+//
+// Provider entries are merged after the team catalog and before the
+// personal overlay.
+
+const (
+	providerPrefix         = "features-provider-"
+	pluginsDir             = "plugins"
+	defaultProviderTimeout = 5 * time.Second
+	providerMaxOutput      = 4 << 20
+)
+
+// sourcePluginPrefix prefixes the Source of provider commands, followed by
+// the provider name.
+const sourcePluginPrefix = "plugin:"
+
+// provider is a discovered plugin executable.
+type provider struct {
+	Name    string
+	Path    string
+	Timeout time.Duration
+}
+
+// providerLoadedMsg carries one provider's result.
+type providerLoadedMsg struct {
+	Name    string
+	Entries []catalogEntry
+	Err     error
+}
+
+// providerTimeout returns the timeout for a provider:
+// FEATURES_PROVIDER_<NAME>_TIMEOUT, then FEATURES_PROVIDER_TIMEOUT.
+func providerTimeout(name string) time.Duration {
+	env := "FEATURES_PROVIDER_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name)) + "_TIMEOUT"
+	for _, key := range []string{env, "FEATURES_PROVIDER_TIMEOUT"} {
+		if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultProviderTimeout
+}
+
+// discoverProviders lists providers, the plugins dir first and then PATH.
+// The first executable of a given name wins.
+func discoverProviders() []provider {
+	dirs := append([]string{statePath(pluginsDir)}, filepath.SplitList(os.Getenv("PATH"))...)
+	seen := make(map[string]bool)
+	var out []provider
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := providerName(e.Name())
+			if !ok || seen[name] || e.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			out = append(out, provider{Name: name, Path: path, Timeout: providerTimeout(name)})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// providerName extracts the provider name from a file name.
+func providerName(file string) (string, bool) {
+	if !strings.HasPrefix(file, providerPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, providerPrefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".cmd" && ext != ".bat" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
+
+// loadProvidersCmds starts every provider in the background.
+func loadProvidersCmds(providers []provider) tea.Cmd {
+	cmds := make([]tea.Cmd, len(providers))
+	for i, p := range providers {
+		cmds[i] = func() tea.Msg {
+			entries, err := p.run()
+			return providerLoadedMsg{Name: p.Name, Entries: entries, Err: err}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// run executes the provider and parses its output.
+func (p provider) run() ([]catalogEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Stdout = &limitedBuffer{buf: &stdout, max: providerMaxOutput}
+	cmd.Stderr = &limitedBuffer{buf: &stderr, max: 4096}
+	cmd.WaitDelay = time.Second // don't wait on children holding the pipes
+	err := cmd.Run()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s", p.Timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, firstLine(msg))
+		}
+		return nil, err
+	}
+	return parseProviderOutput(stdout.Bytes())
+}
+
+func parseProviderOutput(data []byte) ([]catalogEntry, error) {
+	f, err := parseCatalog(data)
+	return f.Commands, err
+}
+
+// limitedBuffer stops collecting output after max bytes.
+type limitedBuffer struct {
+	buf *bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return s
+}
+
+// handleProviderLoaded stores a provider's entries and rebuilds the catalog.
+func (m *Model) handleProviderLoaded(msg providerLoadedMsg) {
+	if msg.Err != nil {
+		m.showToast(fmt.Sprintf("Provider %s: %v", msg.Name, msg.Err), "error")
+		return
+	}
+	plugins := make(map[string][]catalogEntry, len(m.plugins)+1)
+	for k, v := range m.plugins {
+		plugins[k] = v
+	}
+	plugins[msg.Name] = msg.Entries
+	m.plugins = plugins
+	m.reloadCatalog()
+	m.calculateLayout()
+}
+
+// mergePlugins merges provider entries in provider name order.
+func (m *Model) mergePlugins(cats []Category) []Category {
+	names := make([]string, 0, len(m.plugins))
+	for name := range m.plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cats = mergeEntries(cats, m.plugins[name], sourcePluginPrefix+name)
+	}
+	return cats
+}
+
+// pluginHas reports whether a provider supplied cmd in category catID.
+func (m *Model) pluginHas(catID, cmd string) bool {
+	for _, entries := range m.plugins {
+		for _, e := range entries {
+			if e.Category == catID && e.Cmd == cmd && !e.Deleted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// refreshProviders rediscovers and reruns all providers.
+func (m *Model) refreshProviders() tea.Cmd {
+	m.providers = discoverProviders()
+
+	// Forget providers that are gone
+	plugins := make(map[string][]catalogEntry, len(m.providers))
+	for _, p := range m.providers {
+		if entries, ok := m.plugins[p.Name]; ok {
+			plugins[p.Name] = entries
+		}
+	}
+	if len(plugins) != len(m.plugins) {
+		m.plugins = plugins
+		m.reloadCatalog()
+	}
+
+	if len(m.providers) == 0 {
+		m.showToast("No providers found (features-provider-* on PATH)", "info")
+		return nil
+	}
+	m.showToast(fmt.Sprintf("Running %d providers…", len(m.providers)), "info")
+	return loadProvidersCmds(m.providers)
+}
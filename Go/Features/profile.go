@@ -0,0 +1,340 @@
+// profile.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         PROFILE DEFINITIONS
+// ══════════════════════════════════════════════════════════════════
+
+// Definition kinds
+const (
+	defFunction = "function"
+	defAlias    = "alias"
+)
+
+// Definition is one place a name is defined in a profile script.
+type Definition struct {
+	Name   string
+	Kind   string // defFunction or defAlias
+	Target string // alias target
+	File   string
+	Line   int  // 1-based
+	Nested bool // local helper function inside another block
+}
+
+// Location formats the definition as file:line.
+func (d Definition) Location() string {
+	return fmt.Sprintf("%s:%d", filepath.Base(d.File), d.Line)
+}
+
+// profileIndex holds every definition found in the configured profiles.
+type profileIndex struct {
+	Files   []string
+	Lines   map[string][]string     // file -> source lines
+	Defs    map[string][]Definition // lower-case name -> definitions
+	Aliases map[string][]Definition // lower-case alias target -> aliases
+	Shadows map[string]string       // lower-case name -> shadowed executable path
+}
+
+type profileLoadedMsg profileIndex
+
+// commonExecutables are names that a profile definition should not shadow
+// without a good reason, in addition to anything found on PATH.
+var commonExecutables = map[string]bool{
+	"cd": true, "ls": true, "cat": true, "cp": true, "mv": true, "rm": true,
+	"del": true, "dir": true, "echo": true, "grep": true, "find": true,
+	"sort": true, "kill": true, "ps": true, "curl": true, "wget": true,
+	"sudo": true, "top": true, "touch": true, "nano": true, "open": true,
+	"tree": true, "where": true, "which": true, "history": true, "man": true,
+	"clear": true, "type": true, "start": true, "diff": true, "env": true,
+	"search": true, "install": true, "winget": true, "git": true,
+}
+
+var (
+	functionRe = regexp.MustCompile(`(?i)^(\s*)function\s+(?:(global|script|local|private):)?([\w.\-]+)`)
+	aliasCmdRe = regexp.MustCompile(`(?i)^\s*(Set-Alias|New-Alias)\s+(.*)$`)
+	aliasTokRe = regexp.MustCompile(`"[^"]*"|'[^']*'|\S+`)
+)
+
+// profilePaths returns the profile scripts to scan. FEATURES_PROFILE may
+// hold a path list; otherwise the standard pwsh/Windows PowerShell
+// locations are used.
+func profilePaths() []string {
+	if p := os.Getenv("FEATURES_PROFILE"); p != "" {
+		return filepath.SplitList(p)
+	}
+
+	home, _ := os.UserHomeDir()
+	const name = "Microsoft.PowerShell_profile.ps1"
+	if runtime.GOOS == "windows" {
+		docs := filepath.Join(home, "Documents")
+		return []string{
+			filepath.Join(docs, "PowerShell", name),
+			filepath.Join(docs, "WindowsPowerShell", name),
+		}
+	}
+	return []string{filepath.Join(home, ".config", "powershell", name)}
+}
+
+// loadProfileCmd scans the profiles in the background.
+func loadProfileCmd() tea.Msg {
+	return profileLoadedMsg(scanProfiles(profilePaths()))
+}
+
+func scanProfiles(paths []string) profileIndex {
+	idx := profileIndex{
+		Lines:   make(map[string][]string),
+		Defs:    make(map[string][]Definition),
+		Aliases: make(map[string][]Definition),
+		Shadows: make(map[string]string),
+	}
+	for _, path := range paths {
+		lines, err := readLines(path)
+		if err != nil {
+			continue
+		}
+		idx.Files = append(idx.Files, path)
+		idx.Lines[path] = lines
+		for _, d := range scanProfile(path, lines) {
+			key := strings.ToLower(d.Name)
+			idx.Defs[key] = append(idx.Defs[key], d)
+			if d.Kind == defAlias {
+				t := strings.ToLower(d.Target)
+				idx.Aliases[t] = append(idx.Aliases[t], d)
+			}
+		}
+	}
+
+	for key, defs := range idx.Defs {
+		if !topLevel(defs) {
+			continue
+		}
+		if p, err := exec.LookPath(key); err == nil {
+			idx.Shadows[key] = p
+		} else if commonExecutables[key] {
+			idx.Shadows[key] = key
+		}
+	}
+	return idx
+}
+
+// scanProfile extracts function and alias definitions from one script.
+func scanProfile(path string, lines []string) []Definition {
+	var defs []Definition
+	for i, line := range lines {
+		n := i + 1
+
+		if m := functionRe.FindStringSubmatch(line); m != nil {
+			scope := strings.ToLower(m[2])
+			defs = append(defs, Definition{
+				Name:   m[3],
+				Kind:   defFunction,
+				File:   path,
+				Line:   n,
+				Nested: m[1] != "" && scope != "global",
+			})
+			continue
+		}
+
+		if m := aliasCmdRe.FindStringSubmatch(line); m != nil {
+			name, target := parseAliasArgs(m[2])
+			if name == "" || strings.HasPrefix(name, "$") {
+				continue
+			}
+			defs = append(defs, Definition{
+				Name:   name,
+				Kind:   defAlias,
+				Target: target,
+				File:   path,
+				Line:   n,
+			})
+		}
+	}
+	return defs
+}
+
+// parseAliasArgs reads -Name/-Value (or positional) arguments of
+// Set-Alias/New-Alias.
+func parseAliasArgs(args string) (name, value string) {
+	var positional []string
+	toks := aliasTokRe.FindAllString(args, -1)
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		if strings.HasPrefix(tok, "#") {
+			break
+		}
+		if strings.HasPrefix(tok, "-") {
+			param := strings.ToLower(strings.TrimPrefix(tok, "-"))
+			if i+1 < len(toks) && (param == "name" || param == "value") {
+				i++
+				if param == "name" {
+					name = unquote(toks[i])
+				} else {
+					value = unquote(toks[i])
+				}
+			} else if i+1 < len(toks) && !strings.HasPrefix(toks[i+1], "-") &&
+				(param == "scope" || param == "option" || param == "description") {
+				i++
+			}
+			continue
+		}
+		positional = append(positional, unquote(tok))
+	}
+	if name == "" && len(positional) > 0 {
+		name, positional = positional[0], positional[1:]
+	}
+	if value == "" && len(positional) > 0 {
+		value = positional[0]
+	}
+	return name, value
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// topLevel reports whether any definition is visible outside its block.
+func topLevel(defs []Definition) bool {
+	for _, d := range defs {
+		if !d.Nested {
+			return true
+		}
+	}
+	return false
+}
+
+// definitionsOf returns the top-level definitions of name.
+func (p profileIndex) definitionsOf(name string) []Definition {
+	var out []Definition
+	for _, d := range p.Defs[strings.ToLower(name)] {
+		if !d.Nested {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// aliasesOf returns the aliases whose target is name.
+func (p profileIndex) aliasesOf(name string) []Definition {
+	return p.Aliases[strings.ToLower(name)]
+}
+
+// Conflict is a name defined more than once or shadowing an executable.
+type Conflict struct {
+	Name    string
+	Defs    []Definition
+	Shadows string
+}
+
+// Reason describes why the name is flagged.
+func (c Conflict) Reason() string {
+	var parts []string
+	if len(c.Defs) > 1 {
+		parts = append(parts, fmt.Sprintf("defined %d times", len(c.Defs)))
+	}
+	if c.Shadows != "" {
+		parts = append(parts, "shadows "+c.Shadows)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// conflicts lists flagged names sorted by name.
+func (p profileIndex) conflicts() []Conflict {
+	var out []Conflict
+	for key := range p.Defs {
+		defs := p.definitionsOf(key)
+		shadow := p.Shadows[key]
+		if len(defs) == 0 || (len(defs) < 2 && shadow == "") {
+			continue
+		}
+		out = append(out, Conflict{Name: defs[0].Name, Defs: defs, Shadows: shadow})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+	return out
+}
+
+// conflictOf returns the conflict for name, if any.
+func (p profileIndex) conflictOf(name string) (Conflict, bool) {
+	key := strings.ToLower(name)
+	defs := p.definitionsOf(key)
+	if len(defs) == 0 || (len(defs) < 2 && p.Shadows[key] == "") {
+		return Conflict{}, false
+	}
+	return Conflict{Name: name, Defs: defs, Shadows: p.Shadows[key]}, true
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         CONFLICTS SCREEN
+// ══════════════════════════════════════════════════════════════════
+
+func (m Model) handleConflictsKey(key string) (tea.Model, tea.Cmd) {
+	n := len(m.profile.conflicts())
+	switch key {
+	case "up", "k":
+		m.movePanel(-1, n)
+	case "down", "j":
+		m.movePanel(1, n)
+	case "pgup":
+		m.movePanel(-10, n)
+	case "pgdown":
+		m.movePanel(10, n)
+	case "esc", "C":
+		m.setScreen(screenCatalog)
+	}
+	return m, nil
+}
+
+func (m *Model) viewConflicts() string {
+	conflicts := m.profile.conflicts()
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true).Width(16)
+	reasonStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning))
+	locStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+
+	rows := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		var locs []string
+		for _, d := range c.Defs {
+			kind := "fn"
+			if d.Kind == defAlias {
+				kind = "alias→" + d.Target
+			}
+			locs = append(locs, fmt.Sprintf("%s (%s)", d.Location(), kind))
+		}
+		rows = append(rows, fmt.Sprintf(" ⚠ %s %s  %s",
+			nameStyle.Render(c.Name),
+			reasonStyle.Render(c.Reason()),
+			locStyle.Render(strings.Join(locs, ", "))))
+	}
+	if len(rows) == 0 {
+		msg := "  No conflicts found"
+		if len(m.profile.Files) == 0 {
+			msg = "  No profile found (set FEATURES_PROFILE)"
+		}
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.textMuted)).
+			Italic(true).
+			Render(msg))
+	}
+
+	footer := fmt.Sprintf("esc: back • %d conflicts in %d files", len(conflicts), len(m.profile.Files))
+	return m.renderPanel("⚔️ Conflicts", "blood", rows, m.panelIndex, footer)
+}
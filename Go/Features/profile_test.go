@@ -0,0 +1,51 @@
+// profile_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAliasArgs(t *testing.T) {
+	tests := []struct {
+		args        string
+		name, value string
+	}{
+		{"ll Get-ChildItem", "ll", "Get-ChildItem"},
+		{"-Name ll -Value Get-ChildItem", "ll", "Get-ChildItem"},
+		{"-Value Get-ChildItem -Name ll", "ll", "Get-ChildItem"},
+		{"-name 'g s' -value \"git status\"", "g s", "git status"},
+		{"ll Get-ChildItem -Scope Global", "ll", "Get-ChildItem"},
+		{"-Scope Global ll Get-ChildItem", "ll", "Get-ChildItem"},
+		{"-Option AllScope -Force ll Get-ChildItem", "ll", "Get-ChildItem"},
+		{"ll Get-ChildItem # list files", "ll", "Get-ChildItem"},
+		{"ll", "ll", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		name, value := parseAliasArgs(tt.args)
+		if name != tt.name || value != tt.value {
+			t.Errorf("parseAliasArgs(%q) = %q, %q, want %q, %q", tt.args, name, value, tt.name, tt.value)
+		}
+	}
+}
+
+func TestScanProfile(t *testing.T) {
+	lines := []string{
+		"function gs { git status }",
+		"function global:Get-Thing {",
+		"    function helper { }",
+		"}",
+		"Set-Alias -Name g -Value git",
+		"New-Alias $name foo",
+	}
+	want := []Definition{
+		{Name: "gs", Kind: defFunction, File: "p.ps1", Line: 1},
+		{Name: "Get-Thing", Kind: defFunction, File: "p.ps1", Line: 2},
+		{Name: "helper", Kind: defFunction, File: "p.ps1", Line: 3, Nested: true},
+		{Name: "g", Kind: defAlias, Target: "git", File: "p.ps1", Line: 5},
+	}
+	if got := scanProfile("p.ps1", lines); !reflect.DeepEqual(got, want) {
+		t.Errorf("scanProfile() = %+v\nwant %+v", got, want)
+	}
+}
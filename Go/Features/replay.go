@@ -0,0 +1,232 @@
+// replay.go
+package main
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+	"termshot"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         REPLAY & RECORDING
+// ══════════════════════════════════════════════════════════════════
+//
+// termshot.Session plays, records and draws session scripts; this file
+// turns its events into messages and the program's input back into events.
+
+// snapMsg asks the session to write the current frame to a file.
+type snapMsg string
+
+// scriptResizeMsg is a resize from a script. It pins the size: the
+// terminal's own size reports are ignored from then on.
+type scriptResizeMsg tea.WindowSizeMsg
+
+// mouseActionNames and mouseButtonNames give the messages for the names
+// in termshot.MouseActions and termshot.MouseButtons.
+var mouseActionNames = map[string]tea.MouseAction{
+	"press":   tea.MouseActionPress,
+	"release": tea.MouseActionRelease,
+	"motion":  tea.MouseActionMotion,
+}
+
+var mouseButtonNames = map[string]tea.MouseButton{
+	"none":       tea.MouseButtonNone,
+	"left":       tea.MouseButtonLeft,
+	"middle":     tea.MouseButtonMiddle,
+	"right":      tea.MouseButtonRight,
+	"wheelup":    tea.MouseButtonWheelUp,
+	"wheeldown":  tea.MouseButtonWheelDown,
+	"wheelleft":  tea.MouseButtonWheelLeft,
+	"wheelright": tea.MouseButtonWheelRight,
+	"backward":   tea.MouseButtonBackward,
+	"forward":    tea.MouseButtonForward,
+}
+
+// mousePressTypes gives the legacy event type of a button press.
+var mousePressTypes = map[tea.MouseButton]tea.MouseEventType{
+	tea.MouseButtonLeft:       tea.MouseLeft,
+	tea.MouseButtonMiddle:     tea.MouseMiddle,
+	tea.MouseButtonRight:      tea.MouseRight,
+	tea.MouseButtonWheelUp:    tea.MouseWheelUp,
+	tea.MouseButtonWheelDown:  tea.MouseWheelDown,
+	tea.MouseButtonWheelLeft:  tea.MouseWheelLeft,
+	tea.MouseButtonWheelRight: tea.MouseWheelRight,
+	tea.MouseButtonBackward:   tea.MouseBackward,
+	tea.MouseButtonForward:    tea.MouseForward,
+}
+
+// scriptMsgs returns the messages of one script event.
+func scriptMsgs(ev termshot.Event) []tea.Msg {
+	var msgs []tea.Msg
+	switch ev.Kind {
+	case termshot.EventKeys, termshot.EventType:
+		for _, k := range ev.Presses(knownKey) {
+			msgs = append(msgs, keyMsg(k))
+		}
+	case termshot.EventMouse:
+		msgs = append(msgs, mouseMsg(ev.Mouse))
+	case termshot.EventResize:
+		msgs = append(msgs, scriptResizeMsg{Width: ev.Width, Height: ev.Height})
+	case termshot.EventSnap:
+		msgs = append(msgs, snapMsg(ev.File))
+	}
+	return msgs
+}
+
+// keyTypes maps key names as printed by tea.Key.String back to key types.
+var keyTypes = func() map[string]tea.KeyType {
+	names := map[string]tea.KeyType{"space": tea.KeySpace}
+	for t := tea.KeyType(-200); t <= 127; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if name := (tea.Key{Type: t}).String(); name != "" && name != " " {
+			names[name] = t
+		}
+	}
+	return names
+}()
+
+// knownKey reports whether name is a key in keyTypes.
+func knownKey(name string) bool {
+	_, ok := keyTypes[name]
+	return ok
+}
+
+// keyMsg is the message for a key press.
+func keyMsg(k termshot.Key) tea.KeyMsg {
+	if k.Name != "" {
+		msg := tea.KeyMsg{Type: keyTypes[k.Name], Alt: k.Alt}
+		if msg.Type == tea.KeySpace {
+			msg.Runes = []rune{' '}
+		}
+		return msg
+	}
+	if k.Rune == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}, Alt: k.Alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{k.Rune}, Alt: k.Alt}
+}
+
+// mouseMsg is the message for a script mouse event.
+func mouseMsg(m termshot.Mouse) tea.MouseMsg {
+	action, button := mouseActionNames[m.Action], mouseButtonNames[m.Button]
+	msg := tea.MouseMsg{X: m.X, Y: m.Y, Action: action, Button: button,
+		Shift: m.Shift, Alt: m.Alt, Ctrl: m.Ctrl}
+	switch {
+	case action == tea.MouseActionPress:
+		msg.Type = mousePressTypes[button]
+	case action == tea.MouseActionRelease && button == tea.MouseButtonNone:
+		msg.Type = tea.MouseRelease
+	case action == tea.MouseActionMotion:
+		msg.Type = tea.MouseMotion
+	}
+	return msg
+}
+
+// scriptEvent is the script event for an input message, a wait for other
+// messages.
+func scriptEvent(msg tea.Msg) termshot.Event {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Paste || (msg.Type == tea.KeyRunes && len(msg.Runes) > 1) {
+			return termshot.Event{Kind: termshot.EventType, Text: string(msg.Runes)}
+		}
+		return termshot.KeyEvent(msg.String())
+	case tea.MouseMsg:
+		m := termshot.Mouse{X: msg.X, Y: msg.Y, Shift: msg.Shift, Alt: msg.Alt, Ctrl: msg.Ctrl}
+		for name, a := range mouseActionNames {
+			if a == msg.Action {
+				m.Action = name
+			}
+		}
+		for name, b := range mouseButtonNames {
+			if b == msg.Button {
+				m.Button = name
+			}
+		}
+		return termshot.Event{Kind: termshot.EventMouse, Mouse: m}
+	case tea.WindowSizeMsg:
+		return termshot.Event{Kind: termshot.EventResize, Width: msg.Width, Height: msg.Height}
+	}
+	return termshot.Event{}
+}
+
+// ── Sessions ─────────────────────────────────────────────────────
+
+// sessionModel wraps the program's model to hand its input and frames to
+// the session.
+type sessionModel struct {
+	tea.Model
+	s *termshot.Session
+}
+
+func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case snapMsg:
+		m.s.Snap(string(msg), m.Model.View())
+		return m, nil
+	case scriptResizeMsg:
+		m.s.Resize(msg.Width, msg.Height, true)
+		return m.update(tea.WindowSizeMsg(msg))
+	case tea.WindowSizeMsg:
+		if !m.s.Resize(msg.Width, msg.Height, false) {
+			return m, nil
+		}
+	}
+	return m.update(msg)
+}
+
+func (m sessionModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.s.Record(scriptEvent(msg))
+	next, cmd := m.Model.Update(msg)
+	m.Model = next
+	return m, cmd
+}
+
+func (m sessionModel) View() string {
+	v := m.Model.View()
+	m.s.Frame(v)
+	return v
+}
+
+// runSession runs the program, replaying, recording and writing frames as
+// the options ask.
+func runSession(model tea.Model, o termshot.Options, opts ...tea.ProgramOption) error {
+	if !o.Active() {
+		_, err := tea.NewProgram(model, opts...).Run()
+		return err
+	}
+
+	s, err := termshot.NewSession(o, shotPage)
+	if err != nil {
+		return err
+	}
+	if (o.Frames != "" || o.Final != "") && lipgloss.ColorProfile() == termenv.Ascii {
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	}
+	if s.Events != nil && !term.IsTerminal(os.Stdin.Fd()) {
+		// Nothing to read keys from, e.g. in CI: the script is the input.
+		opts = append(opts, tea.WithInput(nil))
+	}
+	p := tea.NewProgram(sessionModel{model, s}, opts...)
+	if s.Events != nil {
+		go s.Play(func(ev termshot.Event) {
+			for _, msg := range scriptMsgs(ev) {
+				p.Send(msg)
+			}
+		}, p.Quit)
+	}
+	final, err := p.Run()
+	if err == nil {
+		s.Finish(final.View())
+	}
+	if cerr := s.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+// replay_test.go
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"termshot"
+)
+
+func TestMouseNames(t *testing.T) {
+	for _, name := range termshot.MouseActions {
+		if _, ok := mouseActionNames[name]; !ok {
+			t.Errorf("no message for mouse action %q", name)
+		}
+	}
+	for _, name := range termshot.MouseButtons {
+		if _, ok := mouseButtonNames[name]; !ok {
+			t.Errorf("no message for mouse button %q", name)
+		}
+	}
+}
+
+func TestScriptMsgs(t *testing.T) {
+	events, err := termshot.ParseScript(strings.NewReader(
+		"key down alt+enter\ntype a b\nresize 80 24\nsnap x.svg\nmouse press left 3 4 alt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []tea.Msg
+	for _, ev := range events {
+		got = append(got, scriptMsgs(ev)...)
+	}
+	want := []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyEnter, Alt: true},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}},
+		tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}},
+		scriptResizeMsg{Width: 80, Height: 24},
+		snapMsg("x.svg"),
+		tea.MouseMsg{X: 3, Y: 4, Alt: true, Action: tea.MouseActionPress,
+			Button: tea.MouseButtonLeft, Type: tea.MouseLeft},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scriptMsgs() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestScriptEvent(t *testing.T) {
+	tests := []struct {
+		msg  tea.Msg
+		want string
+	}{
+		{tea.KeyMsg{Type: tea.KeyCtrlF}, "key ctrl+f"},
+		{tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}, "key space"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hi")}, "type hi"},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Paste: true}, "type x"},
+		{tea.MouseMsg{X: 1, Y: 2, Ctrl: true, Action: tea.MouseActionRelease, Button: tea.MouseButtonNone},
+			"mouse release none 1 2 ctrl"},
+		{tea.WindowSizeMsg{Width: 90, Height: 30}, "resize 90 30"},
+		{snapMsg("x.svg"), ""},
+	}
+	for _, tt := range tests {
+		if got := scriptEvent(tt.msg).String(); got != tt.want {
+			t.Errorf("scriptEvent(%#v) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
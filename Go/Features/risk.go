@@ -0,0 +1,294 @@
+// risk.go
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         RISK & PRIVILEGES
+// ══════════════════════════════════════════════════════════════════
+
+// Risk levels, from harmless to changing how the system behaves. An empty
+// Risk is safe.
+const (
+	riskSafe        = "safe"
+	riskCaution     = "caution"
+	riskDestructive = "destructive"
+	riskSystem      = "system-altering"
+)
+
+// Privileges a command needs, in increasing order. An empty Privilege is
+// user.
+const (
+	privUser    = "user"
+	privAdmin   = "admin"
+	privSystem  = "system"
+	privTrusted = "trustedinstaller"
+)
+
+var (
+	riskLevels = []string{riskSafe, riskCaution, riskDestructive, riskSystem}
+	privileges = []string{privUser, privAdmin, privSystem, privTrusted}
+)
+
+// rankOf returns the position of v in levels, treating "" as the first.
+func rankOf(levels []string, v string) int {
+	for i, l := range levels {
+		if l == v {
+			return i
+		}
+	}
+	return 0
+}
+
+// validLevel reports whether v is empty or one of levels.
+func validLevel(levels []string, v string) bool {
+	if v == "" {
+		return true
+	}
+	for _, l := range levels {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
+
+// RiskLevel returns the command's risk, defaulting to safe.
+func (c Command) RiskLevel() string {
+	if c.Risk == "" {
+		return riskSafe
+	}
+	return c.Risk
+}
+
+// RequiredPrivilege returns the privilege the command needs, defaulting
+// to user.
+func (c Command) RequiredPrivilege() string {
+	if c.Privilege == "" {
+		return privUser
+	}
+	return c.Privilege
+}
+
+// riskBadge is how a risk level is shown in the list and detail pane.
+type riskBadge struct {
+	Icon  string
+	Label string
+	Color string
+}
+
+func badgeForRisk(risk string) riskBadge {
+	switch risk {
+	case riskCaution:
+		return riskBadge{"⚠", "Caution", colors.warning}
+	case riskDestructive:
+		return riskBadge{"✖", "Destructive", colors.error}
+	case riskSystem:
+		return riskBadge{"☢", "System-altering", colors.secondary}
+	}
+	return riskBadge{"✓", "Safe", colors.success}
+}
+
+// privilegeLabel names a privilege for display; short is used in the list.
+func privilegeLabel(priv string) (long, short string) {
+	switch priv {
+	case privAdmin:
+		return "Administrator", "A"
+	case privSystem:
+		return "SYSTEM", "S"
+	case privTrusted:
+		return "TrustedInstaller", "TI"
+	}
+	return "User", ""
+}
+
+// ── Elevation ────────────────────────────────────────────────────
+
+// elevationMsg carries the privilege the process runs with.
+type elevationMsg string
+
+// Windows integrity level SIDs and the TrustedInstaller service SID, as
+// listed by `whoami /groups`.
+const (
+	sidHighIntegrity   = "S-1-16-12288"
+	sidSystemIntegrity = "S-1-16-16384"
+	sidTrustedInstall  = "S-1-5-80-956008885-3418522649-1831038044-1853292631-2271478464"
+)
+
+// detectElevationCmd finds out whether Features runs elevated: root on
+// Unix, and the integrity level and groups of the token on Windows.
+func detectElevationCmd() tea.Msg {
+	if runtime.GOOS != "windows" {
+		if os.Geteuid() == 0 {
+			return elevationMsg(privAdmin)
+		}
+		return elevationMsg(privUser)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "whoami", "/groups").Output()
+	if err != nil {
+		return elevationMsg(privUser)
+	}
+	return elevationMsg(parseWhoamiGroups(string(out)))
+}
+
+// parseWhoamiGroups maps `whoami /groups` output to a privilege.
+func parseWhoamiGroups(out string) string {
+	switch {
+	case strings.Contains(out, sidTrustedInstall):
+		return privTrusted
+	case strings.Contains(out, sidSystemIntegrity):
+		return privSystem
+	case strings.Contains(out, sidHighIntegrity):
+		return privAdmin
+	}
+	return privUser
+}
+
+// canRun reports whether the process has the privilege c needs.
+func (m *Model) canRun(c Command) bool {
+	return rankOf(privileges, m.elevation) >= rankOf(privileges, c.RequiredPrivilege())
+}
+
+// ── Filters ──────────────────────────────────────────────────────
+
+// privRunnable is the privilege filter showing what can run right now.
+const privRunnable = "runnable"
+
+// riskFilters and privFilters are cycled through by ! and ^; "" shows all.
+var (
+	riskFilters = append([]string{""}, riskLevels...)
+	privFilters = append([]string{"", privRunnable}, privileges...)
+)
+
+// matchesRiskFilters applies the risk and privilege filters to c.
+func (m *Model) matchesRiskFilters(c Command) bool {
+	if m.riskFilter != "" && c.RiskLevel() != m.riskFilter {
+		return false
+	}
+	switch m.privFilter {
+	case "":
+		return true
+	case privRunnable:
+		return m.canRun(c)
+	}
+	return c.RequiredPrivilege() == m.privFilter
+}
+
+// riskFiltered drops the commands hidden by the risk and privilege filters.
+func (m *Model) riskFiltered(cmds []Command) []Command {
+	if m.riskFilter == "" && m.privFilter == "" {
+		return cmds
+	}
+	out := make([]Command, 0, len(cmds))
+	for _, c := range cmds {
+		if m.matchesRiskFilters(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// nextFilter returns the value after cur in filters, wrapping around.
+func nextFilter(filters []string, cur string) string {
+	for i, f := range filters {
+		if f == cur {
+			return filters[(i+1)%len(filters)]
+		}
+	}
+	return ""
+}
+
+func (m *Model) cycleRiskFilter() {
+	m.riskFilter = nextFilter(riskFilters, m.riskFilter)
+	m.resetSelection()
+	if m.riskFilter == "" {
+		m.showToast("Risk filter: all", "info")
+		return
+	}
+	m.showToast("Risk filter: "+badgeForRisk(m.riskFilter).Label, "info")
+}
+
+func (m *Model) cyclePrivFilter() {
+	m.privFilter = nextFilter(privFilters, m.privFilter)
+	m.resetSelection()
+	switch m.privFilter {
+	case "":
+		m.showToast("Privilege filter: all", "info")
+	case privRunnable:
+		long, _ := privilegeLabel(m.elevation)
+		m.showToast("Privilege filter: runnable as "+long, "info")
+	default:
+		long, _ := privilegeLabel(m.privFilter)
+		m.showToast("Privilege filter: needs "+long, "info")
+	}
+}
+
+// filterLabel describes the active risk and privilege filters for the
+// list header, or "" when none is set.
+func (m *Model) filterLabel() string {
+	var parts []string
+	if m.riskFilter != "" {
+		parts = append(parts, badgeForRisk(m.riskFilter).Icon+" "+m.riskFilter)
+	}
+	if m.privFilter == privRunnable {
+		parts = append(parts, "runnable")
+	} else if m.privFilter != "" {
+		parts = append(parts, "needs "+m.privFilter)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// ── Rendering ────────────────────────────────────────────────────
+
+// riskMarks renders the compact risk and privilege badges for the list.
+func riskMarks(c Command) string {
+	var b strings.Builder
+	if risk := c.RiskLevel(); risk != riskSafe {
+		badge := badgeForRisk(risk)
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(badge.Color)).Render(badge.Icon))
+	}
+	if _, short := privilegeLabel(c.RequiredPrivilege()); short != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent)).Render("[" + short + "]"))
+	}
+	return b.String()
+}
+
+// riskLines renders the risk section of the detail pane.
+func (m *Model) riskLines(c Command) []string {
+	risk, priv := c.RiskLevel(), c.RequiredPrivilege()
+	if risk == riskSafe && priv == privUser {
+		return nil
+	}
+	badge := badgeForRisk(risk)
+	badgeStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Foreground(lipgloss.Color(badge.Color)).
+		Bold(true).
+		Padding(0, 1)
+	long, _ := privilegeLabel(priv)
+	privStyle := lipgloss.NewStyle().
+		Background(lipgloss.Color(colors.bgDark)).
+		Foreground(lipgloss.Color(colors.accent)).
+		Padding(0, 1)
+
+	lines := []string{"  " + badgeStyle.Render(badge.Icon+" "+badge.Label) + " " + privStyle.Render("🔑 "+long)}
+	if !m.canRun(c) {
+		have, _ := privilegeLabel(m.elevation)
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning)).
+			Render("  Not elevated enough: running as "+have))
+	}
+	return lines
+}
@@ -0,0 +1,94 @@
+// run.go
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         RUNNING COMMANDS
+// ══════════════════════════════════════════════════════════════════
+
+// execDoneMsg reports the end of a command run in the foreground.
+type execDoneMsg struct {
+	Line string
+	Err  error
+}
+
+// shellCommand builds an exec.Cmd running line in PowerShell (so profile
+// functions resolve), falling back to the platform shell. The shell waits
+// for Enter afterwards so the output can be read before the TUI returns,
+// and exits non-zero when the command failed.
+func shellCommand(line string) *exec.Cmd {
+	for _, sh := range []string{"pwsh", "powershell"} {
+		if p, err := exec.LookPath(sh); err == nil {
+			script := fmt.Sprintf("$global:LASTEXITCODE = 0; & { %s }; $ok = $? -and $LASTEXITCODE -eq 0; "+
+				"Write-Host ''; Read-Host 'Press Enter to return' | Out-Null; if (-not $ok) { exit 1 }", line)
+			return exec.Command(p, "-NoLogo", "-Command", script)
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", line+" & pause")
+	}
+	script := line + "\ns=$?; printf '\\nPress Enter to return'; read _; exit $s"
+	return exec.Command("sh", "-c", script)
+}
+
+// psQuote returns s as a PowerShell single-quoted literal, so it is passed
+// as one argument whatever it contains. PowerShell also closes quotes on
+// the typographic single quotes, so those are doubled as well.
+func psQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'', '\u2018', '\u2019', '\u201a', '\u201b':
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// runLine suspends the TUI and runs line in the terminal.
+func runLine(line string) tea.Cmd {
+	return tea.ExecProcess(shellCommand(line), func(err error) tea.Msg {
+		return execDoneMsg{Line: line, Err: err}
+	})
+}
+
+// confirmRisky calls run right away when every command in cmds is safe,
+// and otherwise asks first, naming the highest risk among them.
+func (m *Model) confirmRisky(line string, cmds []Command, run func(*Model) tea.Cmd) tea.Cmd {
+	risk := riskSafe
+	for _, c := range cmds {
+		if rankOf(riskLevels, c.RiskLevel()) > rankOf(riskLevels, risk) {
+			risk = c.RiskLevel()
+		}
+	}
+	if risk == riskSafe {
+		return run(m)
+	}
+	b := badgeForRisk(risk)
+	m.askConfirmCmd(fmt.Sprintf("%s %s: run %s?", b.Icon, b.Label, line), run)
+	return nil
+}
+
+// handleExecDone reports the outcome of a run as a toast.
+func (m *Model) handleExecDone(msg execDoneMsg) {
+	if m.run != nil && m.run.Waiting {
+		m.workflowStepDone(msg.Err)
+		return
+	}
+	if msg.Err != nil {
+		m.showToast(fmt.Sprintf("Run failed: %v", msg.Err), "error")
+		return
+	}
+	m.showToast(fmt.Sprintf("Ran: %s", msg.Line), "success")
+}
@@ -0,0 +1,62 @@
+// run_test.go
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRunAsksUnlessSafe(t *testing.T) {
+	tests := []struct {
+		name string
+		cmds []Command
+		ask  bool
+	}{
+		{"safe", []Command{{Cmd: "gs"}}, false},
+		{"explicitly safe", []Command{{Cmd: "gs", Risk: riskSafe}}, false},
+		{"caution", []Command{{Cmd: "kill", Risk: riskCaution}}, true},
+		{"destructive", []Command{{Cmd: "nuke", Risk: riskDestructive}}, true},
+		{"system", []Command{{Cmd: "def", Risk: riskSystem}}, true},
+		{"one risky in a pipeline", []Command{{Cmd: "ls"}, {Cmd: "trash", Risk: riskDestructive}}, true},
+	}
+	for _, tt := range tests {
+		m := Model{config: defaultConfig()}
+		ran := false
+		m.confirmRisky("line", tt.cmds, func(*Model) tea.Cmd {
+			ran = true
+			return nil
+		})
+		if asked := m.confirm != nil; asked != tt.ask || ran == tt.ask {
+			t.Errorf("%s: asked %v, ran %v, want asked %v", tt.name, asked, ran, tt.ask)
+		}
+	}
+}
+
+func TestItemActionRunConfirmsRisky(t *testing.T) {
+	m := Model{config: defaultConfig()}
+	if cmd := m.itemAction(actRun, Command{Cmd: "nuke", Risk: riskDestructive}); cmd != nil || m.confirm == nil {
+		t.Fatal("destructive command ran without asking")
+	}
+	if cmd := m.confirm(&m); cmd == nil {
+		t.Error("confirming did not run the command")
+	}
+}
+
+func TestPsQuote(t *testing.T) {
+	tests := map[string]string{
+		"":        "''",
+		"main":    "'main'",
+		"x;calc":  "'x;calc'",
+		"$(evil)": "'$(evil)'",
+		"`n":      "'`n'",
+		"it's":    "'it''s'",
+		"''":      "''''''",
+		"a‘b":     "'a‘‘b'",
+	}
+	for in, want := range tests {
+		if got := psQuote(in); got != want {
+			t.Errorf("psQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
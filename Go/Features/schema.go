@@ -0,0 +1,140 @@
+// schema.go
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         CATALOG FORMAT & SCHEMA
+// ══════════════════════════════════════════════════════════════════
+//
+// The personal catalog, team catalogs and provider output share one
+// format, described by catalog.schema.json (printed by `features schema`).
+// Files carry a schemaVersion; older versions are migrated on load and the
+// personal catalog is written back in the current version.
+
+// catalogSchemaVersion is the format version written by Features.
+const catalogSchemaVersion = 2
+
+//go:embed catalog.schema.json
+var catalogSchema []byte
+
+// catalogCategory groups commands under a category in a catalog file.
+type catalogCategory struct {
+	ID       string         `json:"id,omitempty"`
+	Name     string         `json:"name,omitempty"`
+	Icon     string         `json:"icon,omitempty"`
+	Parent   string         `json:"parent,omitempty"`
+	Commands []catalogEntry `json:"commands"`
+}
+
+// catalogFile is the on-disk catalog format.
+type catalogFile struct {
+	Schema        string            `json:"$schema,omitempty"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Name          string            `json:"name,omitempty"`
+	Categories    []catalogCategory `json:"categories,omitempty"`
+	Commands      []catalogEntry    `json:"commands"`
+}
+
+// catalogMigrations[v] upgrades a file from version v to v+1.
+var catalogMigrations = map[int]func(*catalogFile){
+	1: migrateCatalogV1,
+}
+
+// migrateCatalogV1 replaces the danger flag of version 1 with a risk level
+// and privilege.
+func migrateCatalogV1(f *catalogFile) {
+	migrate := func(entries []catalogEntry) {
+		for i := range entries {
+			e := &entries[i]
+			if e.Danger && e.Risk == "" && e.Privilege == "" {
+				e.Risk, e.Privilege = riskCaution, privAdmin
+			}
+			e.Danger = false
+		}
+	}
+	migrate(f.Commands)
+	for i := range f.Categories {
+		migrate(f.Categories[i].Commands)
+	}
+}
+
+// parseCatalog decodes a catalog file, migrates it to the current version
+// and flattens grouped categories into Commands.
+func parseCatalog(data []byte) (catalogFile, error) {
+	var f catalogFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if f.SchemaVersion == 0 {
+		f.SchemaVersion = 1
+	}
+	if f.SchemaVersion > catalogSchemaVersion {
+		return f, fmt.Errorf("schema version %d is newer than supported (%d)", f.SchemaVersion, catalogSchemaVersion)
+	}
+	for ; f.SchemaVersion < catalogSchemaVersion; f.SchemaVersion++ {
+		if migrate := catalogMigrations[f.SchemaVersion]; migrate != nil {
+			migrate(&f)
+		}
+	}
+
+	for _, c := range f.Categories {
+		if c.ID == "" {
+			c.ID = slugify(c.Name)
+		}
+		for _, e := range c.Commands {
+			e.Category = c.ID
+			e.CategoryName = c.Name
+			e.CategoryIcon = c.Icon
+			e.Parent = c.Parent
+			f.Commands = append(f.Commands, e)
+		}
+	}
+	f.Categories = nil
+	return f, f.validate()
+}
+
+var platformRe = regexp.MustCompile(`^(windows|linux|macos|pwsh>=[0-9]+(\.[0-9]+)*)$`)
+
+// validPlatform reports whether p is a known Platforms value.
+func validPlatform(p string) bool {
+	return platformRe.MatchString(p)
+}
+
+// validate checks the entries against the rules of the schema.
+func (f catalogFile) validate() error {
+	for _, e := range f.Commands {
+		if e.Category == "" || e.Cmd == "" {
+			return errors.New("entry without category or cmd")
+		}
+		if !validLevel(riskLevels, e.Risk) {
+			return fmt.Errorf("%s: unknown risk %q", e.Cmd, e.Risk)
+		}
+		if !validLevel(privileges, e.Privilege) {
+			return fmt.Errorf("%s: unknown privilege %q", e.Cmd, e.Privilege)
+		}
+		for _, p := range e.Platforms {
+			if !validPlatform(p) {
+				return fmt.Errorf("%s: unknown platform %q", e.Cmd, p)
+			}
+		}
+	}
+	return nil
+}
+
+// runSchema prints the catalog JSON Schema.
+func runSchema(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: features schema (unexpected %s)", strings.Join(args, " "))
+	}
+	_, err := os.Stdout.Write(catalogSchema)
+	return err
+}
@@ -0,0 +1,62 @@
+// schema_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCatalog(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []catalogEntry
+	}{
+		{"v1 danger becomes caution/admin",
+			`{"commands":[{"category":"x","cmd":"a","danger":true},{"category":"x","cmd":"b"}]}`,
+			[]catalogEntry{
+				{Category: "x", Cmd: "a", Risk: riskCaution, Privilege: privAdmin},
+				{Category: "x", Cmd: "b"},
+			}},
+		{"v1 danger keeps an explicit risk",
+			`{"schemaVersion":1,"commands":[{"category":"x","cmd":"a","danger":true,"risk":"destructive"}]}`,
+			[]catalogEntry{{Category: "x", Cmd: "a", Risk: riskDestructive}}},
+		{"v2 is left alone",
+			`{"schemaVersion":2,"commands":[{"category":"x","cmd":"a","risk":"system-altering","privilege":"system"}]}`,
+			[]catalogEntry{{Category: "x", Cmd: "a", Risk: riskSystem, Privilege: privSystem}}},
+		{"grouped categories are flattened",
+			`{"schemaVersion":2,"categories":[{"name":"My Tools","icon":"🔧","parent":"dev","commands":[{"cmd":"a"}]}],"commands":[]}`,
+			[]catalogEntry{{Category: "my-tools", CategoryName: "My Tools", CategoryIcon: "🔧", Parent: "dev", Cmd: "a"}}},
+		{"v1 grouped categories are migrated",
+			`{"categories":[{"id":"t","commands":[{"cmd":"a","danger":true}]}],"commands":[]}`,
+			[]catalogEntry{{Category: "t", Cmd: "a", Risk: riskCaution, Privilege: privAdmin}}},
+	}
+	for _, tt := range tests {
+		f, err := parseCatalog([]byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if f.SchemaVersion != catalogSchemaVersion || f.Categories != nil {
+			t.Errorf("%s: version %d, categories %v", tt.name, f.SchemaVersion, f.Categories)
+		}
+		if !reflect.DeepEqual(f.Commands, tt.want) {
+			t.Errorf("%s: Commands = %+v\nwant %+v", tt.name, f.Commands, tt.want)
+		}
+	}
+}
+
+func TestParseCatalogErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"commands":`,
+		`{"schemaVersion":99,"commands":[]}`,
+		`{"schemaVersion":2,"commands":[{"cmd":"a"}]}`,
+		`{"schemaVersion":2,"commands":[{"category":"x","cmd":"a","risk":"scary"}]}`,
+		`{"schemaVersion":2,"commands":[{"category":"x","cmd":"a","privilege":"root"}]}`,
+		`{"schemaVersion":2,"commands":[{"category":"x","cmd":"a","platforms":["beos"]}]}`,
+	} {
+		if _, err := parseCatalog([]byte(data)); err == nil {
+			t.Errorf("parseCatalog(%s) succeeded, want an error", data)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// screenshot.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"termshot"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         SCREENSHOTS
+// ══════════════════════════════════════════════════════════════════
+//
+// `features screenshot` renders View() without a terminal and converts the
+// ANSI output into SVG or HTML for documentation. The state is set up with
+// flags and a list of key presses sent through Update before rendering.
+
+// runScreenshot renders one frame of the TUI to --out.
+func runScreenshot(args []string) error {
+	fs := flag.NewFlagSet("screenshot", flag.ContinueOnError)
+	width := fs.Int("width", 140, "terminal width in cells")
+	height := fs.Int("height", 45, "terminal height in cells")
+	out := fs.String("out", "screenshot.svg", "output file, .svg or .html")
+	category := fs.String("category", "", "category ID to select")
+	query := fs.String("query", "", "search query")
+	keys := fs.String("keys", "", "space-separated keys to press first, e.g. \"down down tab\"")
+	fs.StringVar(&configOverride, "config", "", "config file (default "+statePath(configFile)+")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *width < 20 || *height < 5 {
+		return errors.New("screenshot needs at least 20x5 cells")
+	}
+	write, err := termshot.Writer(*out)
+	if err != nil {
+		return err
+	}
+
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	lipgloss.SetHasDarkBackground(true)
+
+	m := buildCatalogModel()
+	next, _ := m.Update(tea.WindowSizeMsg{Width: *width, Height: *height})
+	m = next.(Model)
+	if *category != "" {
+		ci := categoryIndex(m.categories, *category)
+		if ci < 0 {
+			return fmt.Errorf("unknown category %q", *category)
+		}
+		m.selectCategory(ci)
+	}
+	if *query != "" {
+		m.searchInput.SetValue(*query)
+		m.updateFiltered()
+		m.resetSelection()
+	}
+	for _, k := range strings.Fields(*keys) {
+		for _, key := range termshot.ParseKey(k, knownKey) {
+			next, _ = m.Update(keyMsg(key))
+			m = next.(Model)
+		}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	write(f, termshot.Cells(m.View(), *width, *height), shotPage())
+	return f.Close()
+}
+
+// shotPage is the page screenshots are drawn on, in the current theme.
+func shotPage() termshot.Page {
+	return termshot.Page{Title: "Features", Bg: colors.bg, Fg: colors.text}
+}
@@ -0,0 +1,449 @@
+// serve.go
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         HTTP/JSON API
+// ══════════════════════════════════════════════════════════════════
+//
+// `features serve` exposes the assembled catalog (built-in, team,
+// providers, personal overlay) to other tools:
+//
+//	GET  /api/health
+//	GET  /api/categories
+//	GET  /api/commands?category=git&lang=de
+//	GET  /api/search?q=branch&category=git&limit=10
+//	GET  /api/usage
+//	POST /api/usage       {"cmd": "gco"}
+//	POST /api/reload
+//
+// With a token (--token or FEATURES_API_TOKEN) every endpoint but
+// /api/health requires "Authorization: Bearer <token>".
+//
+// Web pages must not reach the API through the user's browser: requests
+// with an Origin header are refused, the Host must be loopback or the
+// bound address (against DNS rebinding), and POSTs must be sent as
+// application/json, which a page cannot do cross-site without a CORS
+// preflight. A server bound to every interface with a token accepts any
+// Host, since the token already keeps browsers out.
+
+const (
+	defaultServeAddr = "127.0.0.1:7777"
+	usageFile        = "usage.json"
+	maxRequestBody   = 64 << 10
+)
+
+// apiCategory is a category in API responses.
+type apiCategory struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Icon    string `json:"icon,omitempty"`
+	Parent  string `json:"parent,omitempty"`
+	Virtual bool   `json:"virtual,omitempty"`
+	Count   int    `json:"count"`
+}
+
+// apiCommand is a command in API responses, with its category and use
+// count.
+type apiCommand struct {
+	Cmd       string   `json:"cmd"`
+	Desc      string   `json:"desc,omitempty"`
+	Usage     string   `json:"usage,omitempty"`
+	Example   string   `json:"example,omitempty"`
+	Hot       string   `json:"hot,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Since     string   `json:"since,omitempty"`
+	Risk      string   `json:"risk"`
+	Privilege string   `json:"privilege"`
+	Platforms []string `json:"platforms,omitempty"`
+	Available bool     `json:"available"` // works on the server's OS
+	Category  string   `json:"category"`
+	Source    string   `json:"source,omitempty"`
+	Uses      int      `json:"uses,omitempty"`
+}
+
+// ── Usage counts ─────────────────────────────────────────────────
+
+var usageMu sync.Mutex
+
+// loadUsage reads the use counts shared by the TUI and the server.
+func loadUsage() (map[string]int, error) {
+	usage := make(map[string]int)
+	err := loadJSON(statePath(usageFile), &usage)
+	return usage, err
+}
+
+// recordUsage counts one use of cmd and returns the new count. The file
+// is re-read first so concurrent TUI and server processes add up.
+func recordUsage(cmd string) (int, error) {
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	usage, err := loadUsage()
+	if err != nil {
+		return 0, err
+	}
+	usage[cmd]++
+	return usage[cmd], saveJSON(statePath(usageFile), usage)
+}
+
+// ── Server ───────────────────────────────────────────────────────
+
+type apiServer struct {
+	mu    sync.RWMutex
+	model Model
+	token string
+	addr  string // listen address, for the Host check
+}
+
+// runServe parses the serve flags and serves until interrupted.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", defaultServeAddr, "listen address")
+	token := fs.String("token", os.Getenv("FEATURES_API_TOKEN"), "require this bearer token")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if host, _, err := net.SplitHostPort(*addr); err == nil && *token == "" {
+		switch ip := net.ParseIP(host); {
+		case host == "" || (ip != nil && ip.IsUnspecified()):
+			fmt.Fprintf(os.Stderr, "Warning: serving on %s without a token; only localhost requests are accepted\n", *addr)
+		case host != "localhost" && (ip == nil || !ip.IsLoopback()):
+			fmt.Fprintf(os.Stderr, "Warning: serving on %s without a token\n", *addr)
+		}
+	}
+
+	s := &apiServer{token: *token, addr: *addr}
+	s.model = buildCatalogModel()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving %d commands on http://%s/api\n", s.model.totalCmds, *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// buildCatalogModel assembles the catalog like the TUI does, running the
+// team fetch and the providers synchronously.
+func buildCatalogModel() Model {
+	m := newModel()
+	m.pwsh = string(detectPwshCmd().(pwshVersionMsg))
+	m.elevation = string(detectElevationCmd().(elevationMsg))
+	if m.teamConfig.URL != "" {
+		if msg, ok := fetchTeamCmd(m.teamConfig, false)().(teamLoadedMsg); ok {
+			m.handleTeamLoaded(msg)
+			if msg.Err != nil {
+				fmt.Fprintf(os.Stderr, "Team catalog: %v\n", msg.Err)
+			}
+		}
+	}
+	plugins := make(map[string][]catalogEntry, len(m.providers))
+	for _, p := range m.providers {
+		entries, err := p.run()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Provider %s: %v\n", p.Name, err)
+			continue
+		}
+		plugins[p.Name] = entries
+	}
+	m.plugins = plugins
+	m.reloadCatalog()
+	return m
+}
+
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/health", s.handleHealth)
+	mux.Handle("GET /api/categories", s.auth(s.handleCategories))
+	mux.Handle("GET /api/commands", s.auth(s.handleCommands))
+	mux.Handle("GET /api/search", s.auth(s.handleSearch))
+	mux.Handle("GET /api/usage", s.auth(s.handleUsage))
+	mux.Handle("POST /api/usage", s.auth(s.handleRecordUsage))
+	mux.Handle("POST /api/reload", s.auth(s.handleReload))
+	return s.guard(mux)
+}
+
+// guard refuses browser requests from other sites: see the top of the
+// file.
+func (s *apiServer) guard(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeError(w, http.StatusForbidden, "cross-origin requests are not allowed")
+			return
+		}
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, "unexpected Host "+r.Host)
+			return
+		}
+		if r.Method == http.MethodPost {
+			if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether a request's Host names this server: a
+// loopback name or the bound host, or any host when bound to every
+// interface with a token.
+func (s *apiServer) allowedHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	bound, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return false
+	}
+	if ip := net.ParseIP(bound); bound == "" || (ip != nil && ip.IsUnspecified()) {
+		return s.token != ""
+	}
+	return host != "" && strings.EqualFold(host, bound)
+}
+
+// auth requires the bearer token when one is configured.
+func (s *apiServer) auth(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			got, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+				writeError(w, http.StatusUnauthorized, "missing or invalid token")
+				return
+			}
+		}
+		h(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func (s *apiServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":    "ok",
+		"commands":  s.model.totalCmds,
+		"elevation": s.model.elevation,
+	})
+}
+
+func (s *apiServer) handleCategories(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m := &s.model
+	rows := m.categoryTree(true)
+	out := make([]apiCategory, 0, len(rows))
+	for _, row := range rows {
+		c := m.categories[row.Index]
+		out = append(out, apiCategory{
+			ID:      c.ID,
+			Name:    c.Name,
+			Icon:    c.Icon,
+			Parent:  c.Parent,
+			Virtual: c.Virtual,
+			Count:   len(m.categoryCommands(row.Index)),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *apiServer) handleCommands(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cmds, ok := s.commands(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, cmds)
+}
+
+func (s *apiServer) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 0
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all, ok := s.commands(w, r)
+	if !ok {
+		return
+	}
+
+	// Search with the TUI's matcher, then map back to the API entries
+	byCmd := make(map[string]apiCommand, len(all))
+	for _, c := range all {
+		if _, dup := byCmd[c.Cmd]; !dup {
+			byCmd[c.Cmd] = c
+		}
+	}
+	matches := searchCommands(s.model.sortPinned(s.commandsOf(all)), q.Get("q"), s.model.notes)
+	out := make([]apiCommand, 0, len(matches))
+	for _, c := range matches {
+		if limit > 0 && len(out) == limit {
+			break
+		}
+		out = append(out, byCmd[c.Cmd])
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *apiServer) handleUsage(w http.ResponseWriter, r *http.Request) {
+	usage, err := loadUsage()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, usage)
+}
+
+func (s *apiServer) handleRecordUsage(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Cmd string `json:"cmd"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	req.Cmd = strings.TrimSpace(req.Cmd)
+	if req.Cmd == "" {
+		writeError(w, http.StatusBadRequest, "cmd is required")
+		return
+	}
+	n, err := recordUsage(req.Cmd)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cmd": req.Cmd, "uses": n})
+}
+
+// handleReload rebuilds the catalog, picking up edits to the personal
+// catalog and re-running the team fetch and providers.
+func (s *apiServer) handleReload(w http.ResponseWriter, r *http.Request) {
+	m := buildCatalogModel()
+	s.mu.Lock()
+	s.model = m
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok", "commands": m.totalCmds})
+}
+
+// commands lists the commands of the category in the request (all real
+// categories by default), localized by its lang parameter. It writes the
+// error response itself when the category is unknown.
+func (s *apiServer) commands(w http.ResponseWriter, r *http.Request) ([]apiCommand, bool) {
+	m := &s.model
+	q := r.URL.Query()
+
+	ci := -1
+	if id := q.Get("category"); id != "" {
+		if ci = categoryIndex(m.categories, id); ci < 0 {
+			writeError(w, http.StatusNotFound, "unknown category "+id)
+			return nil, false
+		}
+	}
+	lang := q.Get("lang")
+	if lang == "" {
+		lang = m.locale
+	}
+	usage, _ := loadUsage()
+
+	var out []apiCommand
+	for _, row := range m.categoryTree(true) {
+		cat := m.categories[row.Index]
+		if (ci < 0 && cat.Virtual) || (ci >= 0 && !m.isDescendant(row.Index, ci)) {
+			continue
+		}
+		for _, c := range cat.Commands {
+			out = append(out, apiCommand{
+				Cmd:       c.Cmd,
+				Desc:      c.Description(lang),
+				Usage:     c.UsageText(lang),
+				Example:   c.Example,
+				Hot:       c.Hot,
+				Tags:      c.Tags,
+				Since:     c.Since,
+				Risk:      c.RiskLevel(),
+				Privilege: c.RequiredPrivilege(),
+				Platforms: c.Platforms,
+				Available: m.available(c),
+				Category:  cat.ID,
+				Source:    c.Source,
+				Uses:      usage[c.Cmd],
+			})
+		}
+	}
+	return out, true
+}
+
+// commandsOf finds the catalog commands behind API entries, so searching
+// sees translations and tags exactly like the TUI.
+func (s *apiServer) commandsOf(entries []apiCommand) []Command {
+	byKey := make(map[string]Command)
+	for _, cat := range s.model.categories {
+		for _, c := range cat.Commands {
+			byKey[cat.ID+"\x00"+c.Cmd] = c
+		}
+	}
+	out := make([]Command, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, byKey[e.Category+"\x00"+e.Cmd])
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+// serve_test.go
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServeGuard(t *testing.T) {
+	t.Setenv("FEATURES_HOME", t.TempDir())
+	tests := []struct {
+		name   string
+		addr   string
+		token  string
+		method string
+		path   string
+		host   string
+		header map[string]string
+		want   int
+	}{
+		{"loopback get", "127.0.0.1:7777", "", "GET", "/api/usage", "127.0.0.1:7777", nil, http.StatusOK},
+		{"localhost get", "127.0.0.1:7777", "", "GET", "/api/usage", "localhost:7777", nil, http.StatusOK},
+		{"ipv6 loopback", "[::1]:7777", "", "GET", "/api/health", "[::1]:7777", nil, http.StatusOK},
+		{"rebound host", "127.0.0.1:7777", "", "GET", "/api/usage", "evil.example:7777", nil, http.StatusForbidden},
+		{"rebound host on health", "127.0.0.1:7777", "", "GET", "/api/health", "evil.example", nil, http.StatusForbidden},
+		{"bound address", "192.168.1.5:7777", "t", "GET", "/api/health", "192.168.1.5:7777", nil, http.StatusOK},
+		{"other address", "192.168.1.5:7777", "t", "GET", "/api/health", "10.0.0.1:7777", nil, http.StatusForbidden},
+		{"any interface with token", "0.0.0.0:7777", "t", "GET", "/api/health", "box.lan:7777", nil, http.StatusOK},
+		{"any interface without token", ":7777", "", "GET", "/api/health", "box.lan:7777", nil, http.StatusForbidden},
+		{"origin get", "127.0.0.1:7777", "", "GET", "/api/usage", "127.0.0.1:7777",
+			map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+		{"origin post", "127.0.0.1:7777", "", "POST", "/api/usage", "127.0.0.1:7777",
+			map[string]string{"Origin": "null", "Content-Type": "application/json"}, http.StatusForbidden},
+		{"form post", "127.0.0.1:7777", "", "POST", "/api/usage", "127.0.0.1:7777",
+			map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType},
+		{"post without type", "127.0.0.1:7777", "", "POST", "/api/reload", "127.0.0.1:7777", nil, http.StatusUnsupportedMediaType},
+		{"json post", "127.0.0.1:7777", "", "POST", "/api/usage", "127.0.0.1:7777",
+			map[string]string{"Content-Type": "application/json; charset=utf-8"}, http.StatusOK},
+		{"token still needed", "127.0.0.1:7777", "t", "GET", "/api/usage", "127.0.0.1:7777", nil, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		s := &apiServer{token: tt.token, addr: tt.addr}
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"cmd": "gs"}`))
+		req.Host = tt.host
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		if tt.token != "" && tt.name != "token still needed" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		rec := httptest.NewRecorder()
+		s.routes().ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d (%s)", tt.name, rec.Code, tt.want, strings.TrimSpace(rec.Body.String()))
+		}
+	}
+	if n, err := loadUsage(); err != nil || n["gs"] != 1 {
+		t.Errorf("usage = %v, %v, want gs counted once", n, err)
+	}
+}
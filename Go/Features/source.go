@@ -0,0 +1,285 @@
+// source.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         PROFILE SOURCE VIEW
+// ══════════════════════════════════════════════════════════════════
+
+// maxSourceLines caps how much of a function body is extracted.
+const maxSourceLines = 400
+
+// sourceOf returns the source lines defining name, with the 1-based line
+// number of the first line. Aliases include the target's source.
+func (p profileIndex) sourceOf(name string) (lines []string, start int, def Definition, ok bool) {
+	return p.sourceVia(name, make(map[string]bool))
+}
+
+// sourceVia is sourceOf following alias targets, with seen holding the
+// names already visited; a name seen twice (an alias loop) is not ok.
+func (p profileIndex) sourceVia(name string, seen map[string]bool) (lines []string, start int, def Definition, ok bool) {
+	key := strings.ToLower(name)
+	if seen[key] {
+		return nil, 0, Definition{}, false
+	}
+	seen[key] = true
+	defs := p.definitionsOf(name)
+	if len(defs) == 0 {
+		return nil, 0, Definition{}, false
+	}
+	// The last definition wins in PowerShell
+	def = defs[len(defs)-1]
+	file := p.Lines[def.File]
+	if def.Line-1 >= len(file) {
+		return nil, 0, def, false
+	}
+
+	if def.Kind == defAlias {
+		lines = []string{file[def.Line-1]}
+		if body, _, _, ok := p.sourceVia(def.Target, seen); ok {
+			lines = append(lines, "")
+			lines = append(lines, body...)
+		}
+		return lines, def.Line, def, true
+	}
+
+	end := functionEnd(file, def.Line-1)
+	return file[def.Line-1 : end+1], def.Line, def, true
+}
+
+// functionEnd finds the line closing the block opened at or after start,
+// skipping braces inside strings and comments.
+func functionEnd(lines []string, start int) int {
+	depth := 0
+	opened := false
+	inBlockComment := false
+	hereString := byte(0)
+
+	for i := start; i < len(lines) && i < start+maxSourceLines; i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if hereString != 0 {
+			if strings.HasPrefix(trimmed, string(hereString)+"@") {
+				hereString = 0
+			}
+			continue
+		}
+
+		for j := 0; j < len(line); j++ {
+			c := line[j]
+			if inBlockComment {
+				if c == '#' && j+1 < len(line) && line[j+1] == '>' {
+					inBlockComment = false
+					j++
+				}
+				continue
+			}
+			switch {
+			case c == '<' && j+1 < len(line) && line[j+1] == '#':
+				inBlockComment = true
+				j++
+			case c == '#':
+				j = len(line)
+			case c == '@' && j+1 < len(line) && (line[j+1] == '"' || line[j+1] == '\'') &&
+				strings.TrimSpace(line[j+2:]) == "":
+				hereString = line[j+1]
+				j = len(line)
+			case c == '"' || c == '\'':
+				j = skipString(line, j)
+			case c == '{':
+				depth++
+				opened = true
+			case c == '}':
+				depth--
+				if opened && depth == 0 {
+					return i
+				}
+			}
+		}
+	}
+	return min(len(lines), start+maxSourceLines) - 1
+}
+
+// skipString returns the index of the quote closing the string at i.
+func skipString(line string, i int) int {
+	q := line[i]
+	for j := i + 1; j < len(line); j++ {
+		switch {
+		case q == '"' && line[j] == '`':
+			j++
+		case line[j] == q:
+			if j+1 < len(line) && line[j+1] == q {
+				j++
+				continue
+			}
+			return j
+		}
+	}
+	return len(line)
+}
+
+// readLines loads a file as lines with tabs expanded.
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	return strings.Split(text, "\n"), nil
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         POWERSHELL HIGHLIGHTING
+// ══════════════════════════════════════════════════════════════════
+
+var psKeywords = map[string]bool{
+	"function": true, "filter": true, "param": true, "begin": true, "process": true,
+	"end": true, "if": true, "elseif": true, "else": true, "switch": true,
+	"foreach": true, "for": true, "while": true, "do": true, "until": true,
+	"in": true, "return": true, "break": true, "continue": true, "try": true,
+	"catch": true, "finally": true, "throw": true, "trap": true, "exit": true,
+	"class": true, "enum": true, "using": true,
+}
+
+var (
+	psKeywordStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.secondary)).Bold(true)
+	psStringStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success))
+	psVariableStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary))
+	psCommentStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Italic(true)
+	psOperatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.warning))
+	psCmdletStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.accent))
+	psPlainStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+)
+
+// highlightPS colours one line of PowerShell: keywords, strings,
+// variables, comments, -operators and Verb-Noun cmdlets.
+func highlightPS(line string) string {
+	var b strings.Builder
+	runes := []rune(line)
+	n := len(runes)
+
+	for i := 0; i < n; {
+		r := runes[i]
+		switch {
+		case r == '#' || (r == '<' && i+1 < n && runes[i+1] == '#'):
+			b.WriteString(psCommentStyle.Render(string(runes[i:])))
+			return b.String()
+
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < n {
+				if r == '"' && runes[j] == '`' {
+					j += 2
+					continue
+				}
+				if runes[j] == r {
+					j++
+					break
+				}
+				j++
+			}
+			j = min(j, n)
+			b.WriteString(psStringStyle.Render(string(runes[i:j])))
+			i = j
+
+		case r == '$':
+			j := i + 1
+			if j < n && runes[j] == '{' {
+				for j < n && runes[j] != '}' {
+					j++
+				}
+				j = min(j+1, n)
+			} else {
+				for j < n && (isIdentRune(runes[j]) || runes[j] == ':') {
+					j++
+				}
+			}
+			b.WriteString(psVariableStyle.Render(string(runes[i:j])))
+			i = j
+
+		case r == '-' && i+1 < n && unicode.IsLetter(runes[i+1]) && (i == 0 || !isIdentRune(runes[i-1])):
+			j := i + 1
+			for j < n && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			b.WriteString(psOperatorStyle.Render(string(runes[i:j])))
+			i = j
+
+		case isIdentRune(r):
+			j := i
+			for j < n && (isIdentRune(runes[j]) || runes[j] == '-') {
+				j++
+			}
+			word := string(runes[i:j])
+			switch {
+			case psKeywords[strings.ToLower(word)]:
+				b.WriteString(psKeywordStyle.Render(word))
+			case isCmdletName(word):
+				b.WriteString(psCmdletStyle.Render(word))
+			default:
+				b.WriteString(psPlainStyle.Render(word))
+			}
+			i = j
+
+		default:
+			b.WriteString(psPlainStyle.Render(string(r)))
+			i++
+		}
+	}
+	return b.String()
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isCmdletName reports whether word looks like Verb-Noun.
+func isCmdletName(word string) bool {
+	i := strings.IndexByte(word, '-')
+	return i > 0 && i < len(word)-1 && unicode.IsUpper(rune(word[0])) && unicode.IsUpper(rune(word[i+1]))
+}
+
+// sourceLines renders the source of item for the detail pane.
+func (m *Model) sourceLines(item Command, width int) []string {
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.textMuted)).
+		Italic(true)
+
+	src, start, def, ok := m.profile.sourceOf(commandName(item.Cmd))
+	if !ok {
+		msg := "  No definition found in the profile"
+		if len(m.profile.Files) == 0 {
+			msg = "  No profile found (set FEATURES_PROFILE)"
+		}
+		return []string{"", mutedStyle.Render(msg)}
+	}
+
+	lines := []string{
+		"",
+		mutedStyle.Render(fmt.Sprintf("  📄 %s", def.Location())),
+		"",
+	}
+	numStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted))
+	codeW := max(1, width-10)
+	for i, l := range src {
+		if lipgloss.Width(l) > codeW {
+			l = truncateRunes(l, codeW-1) + "…"
+		}
+		num := ""
+		if def.Kind == defFunction || i == 0 {
+			num = fmt.Sprintf("%4d", start+i)
+		}
+		lines = append(lines, fmt.Sprintf(" %s %s", numStyle.Render(fmt.Sprintf("%4s", num)), highlightPS(l)))
+	}
+	return lines
+}
@@ -0,0 +1,77 @@
+// source_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFunctionEnd(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want int
+	}{
+		{"one line", "function a { b }", 0},
+		{"multi line", "function a {\n  if ($x) {\n    y\n  }\n}\nz", 4},
+		{"brace on next line", "function a\n{\n  b\n}", 3},
+		{"brace in string", "function a {\n  'x}'\n  \"{\"\n}", 3},
+		{"brace in comment", "function a {\n  # }\n  <# }\n  } #>\n}", 4},
+		{"here-string", "function a {\n  @\"\n  }\n\"@\n}", 4},
+		{"unclosed", "function a {\n  b", 1},
+	}
+	for _, tt := range tests {
+		if got := functionEnd(strings.Split(tt.src, "\n"), 0); got != tt.want {
+			t.Errorf("%s: functionEnd() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// profileFrom indexes a profile script written to a temporary file.
+func profileFrom(t *testing.T, src string) profileIndex {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profile.ps1")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return scanProfiles([]string{path})
+}
+
+func TestSourceOf(t *testing.T) {
+	p := profileFrom(t, strings.Join([]string{
+		"function gs {",          // 1
+		"  git status",           // 2
+		"}",                      // 3
+		"Set-Alias st gs",        // 4
+		"Set-Alias self self",    // 5
+		"Set-Alias a b",          // 6
+		"Set-Alias b a",          // 7
+		"Set-Alias s2 st",        // 8
+		"Set-Alias gone missing", // 9
+	}, "\n"))
+
+	tests := []struct {
+		name  string
+		lines []string
+		start int
+		ok    bool
+	}{
+		{"gs", []string{"function gs {", "  git status", "}"}, 1, true},
+		{"st", []string{"Set-Alias st gs", "", "function gs {", "  git status", "}"}, 4, true},
+		{"s2", []string{"Set-Alias s2 st", "", "Set-Alias st gs", "", "function gs {", "  git status", "}"}, 8, true},
+		{"self", []string{"Set-Alias self self"}, 5, true},
+		{"a", []string{"Set-Alias a b", "", "Set-Alias b a"}, 6, true},
+		{"gone", []string{"Set-Alias gone missing"}, 9, true},
+		{"nothing", nil, 0, false},
+	}
+	for _, tt := range tests {
+		lines, start, _, ok := p.sourceOf(tt.name)
+		if !reflect.DeepEqual(lines, tt.lines) || start != tt.start || ok != tt.ok {
+			t.Errorf("sourceOf(%q) = %q, %d, %v, want %q, %d, %v",
+				tt.name, lines, start, ok, tt.lines, tt.start, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// storage.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         USER STATE STORAGE
+// ══════════════════════════════════════════════════════════════════
+
+// stateDir returns the directory holding Features' user files. It honours
+// FEATURES_HOME, otherwise uses the OS config dir (XDG on Linux, AppData
+// on Windows).
+func stateDir() string {
+	if dir := os.Getenv("FEATURES_HOME"); dir != "" {
+		return dir
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "features")
+	}
+	return "."
+}
+
+// statePath joins name onto stateDir.
+func statePath(name string) string {
+	return filepath.Join(stateDir(), name)
+}
+
+// loadJSON decodes the file at path into v. A missing file is not an
+// error; v is left untouched.
+func loadJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// saveJSON writes v as indented JSON, replacing the file atomically.
+func saveJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,305 @@
+// tags.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         TAG BROWSER
+// ══════════════════════════════════════════════════════════════════
+
+// tagFilter restricts the list to commands carrying the selected tags,
+// across all categories.
+type tagFilter struct {
+	Tags []string // lower-case
+	Any  bool     // OR instead of AND
+}
+
+func (f tagFilter) active() bool { return len(f.Tags) > 0 }
+
+func (f tagFilter) has(tag string) bool {
+	for _, t := range f.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// toggle adds or removes tag, keeping the selection order.
+func (f *tagFilter) toggle(tag string) {
+	for i, t := range f.Tags {
+		if t == tag {
+			f.Tags = append(f.Tags[:i:i], f.Tags[i+1:]...)
+			return
+		}
+	}
+	f.Tags = append(f.Tags[:len(f.Tags):len(f.Tags)], tag)
+}
+
+func (f tagFilter) matches(c Command) bool {
+	n := 0
+	for _, t := range f.Tags {
+		for _, ct := range c.Tags {
+			if strings.EqualFold(ct, t) {
+				n++
+				break
+			}
+		}
+	}
+	if f.Any {
+		return n > 0
+	}
+	return n == len(f.Tags)
+}
+
+// String describes the filter, e.g. "git AND branch".
+func (f tagFilter) String() string {
+	op := " AND "
+	if f.Any {
+		op = " OR "
+	}
+	return strings.Join(f.Tags, op)
+}
+
+type tagCount struct {
+	Tag   string
+	Count int
+}
+
+// catalogCommands returns every command of the real categories once.
+func (m *Model) catalogCommands() []Command {
+	seen := make(map[string]bool)
+	var out []Command
+	for _, r := range m.categoryTree(true) {
+		if m.categories[r.Index].Virtual {
+			continue
+		}
+		for _, c := range m.categories[r.Index].Commands {
+			if !seen[c.Cmd] {
+				seen[c.Cmd] = true
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// taggedCommands returns the catalog commands matching the tag filter.
+func (m *Model) taggedCommands() []Command {
+	var out []Command
+	for _, c := range m.catalogCommands() {
+		if m.tagFilter.matches(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// tagCounts counts the commands per tag, sorted by tag.
+func (m *Model) tagCounts() []tagCount {
+	counts := make(map[string]int)
+	for _, c := range m.catalogCommands() {
+		seen := make(map[string]bool)
+		for _, t := range c.Tags {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "" && !seen[t] {
+				seen[t] = true
+				counts[t]++
+			}
+		}
+	}
+	out := make([]tagCount, 0, len(counts))
+	for t, n := range counts {
+		out = append(out, tagCount{t, n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tag < out[j].Tag })
+	return out
+}
+
+// applyTagFilter rebuilds the list after the tag filter changed.
+func (m *Model) applyTagFilter() {
+	m.resetSelection()
+	m.detailScroll = 0
+}
+
+// clearTagFilter drops the tag filter, going back to the category.
+func (m *Model) clearTagFilter() {
+	m.tagFilter = tagFilter{}
+	m.applyTagFilter()
+	m.showToast("Tag filter cleared", "info")
+}
+
+// tagCloud lays the tags out in rows of at most width columns. It returns
+// the rendered rows and, per row, the tag indices it holds.
+func (m *Model) tagCloud(tags []tagCount, width int) ([]string, [][]int) {
+	maxCount := 1
+	for _, t := range tags {
+		maxCount = max(maxCount, t.Count)
+	}
+
+	tierStyles := []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text)),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.secondary)).Bold(true),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true),
+	}
+	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+	selStyle := lipgloss.NewStyle().Background(lipgloss.Color(colors.success)).Foreground(lipgloss.Color(colors.bgDark)).Bold(true)
+
+	var rows []string
+	var index [][]int
+	var line strings.Builder
+	var ids []int
+	lineW := 0
+	flush := func() {
+		if len(ids) > 0 {
+			rows = append(rows, " "+line.String())
+			index = append(index, ids)
+		}
+		line.Reset()
+		ids = nil
+		lineW = 0
+	}
+
+	for i, t := range tags {
+		// Frequent tags stand out by colour, weight and case
+		tier := min(3, t.Count*4/(maxCount+1))
+		label := t.Tag
+		if tier == 3 {
+			label = strings.ToUpper(label)
+		}
+		style := tierStyles[tier]
+		if m.tagFilter.has(t.Tag) {
+			style = selStyle
+			label = "✓ " + label
+		}
+		if i == m.panelIndex {
+			style = style.Underline(true)
+			if !m.tagFilter.has(t.Tag) {
+				style = style.Background(lipgloss.Color(colors.surfaceHL))
+			}
+		}
+		cell := style.Render(" "+label+" ") + countStyle.Render(fmt.Sprint(t.Count))
+		w := lipgloss.Width(cell) + 2
+		if lineW > 0 && lineW+w > width {
+			flush()
+		}
+		line.WriteString(cell + "  ")
+		ids = append(ids, i)
+		lineW += w
+	}
+	flush()
+	return rows, index
+}
+
+// tagCloudWidth is the room for tags inside the panel border.
+func (m *Model) tagCloudWidth() int {
+	return m.width - m.layout.Padding*2 - 2 - m.layout.SideW - 4
+}
+
+// moveTagRow moves the cursor to the nearest tag in the row delta away.
+func (m *Model) moveTagRow(delta int, index [][]int) {
+	for r, ids := range index {
+		for c, id := range ids {
+			if id != m.panelIndex {
+				continue
+			}
+			nr := max(0, min(len(index)-1, r+delta))
+			m.panelIndex = index[nr][min(c, len(index[nr])-1)]
+			return
+		}
+	}
+}
+
+func (m Model) handleTagsKey(key string) (tea.Model, tea.Cmd) {
+	tags := m.tagCounts()
+	_, index := m.tagCloud(tags, m.tagCloudWidth())
+	switch key {
+	case "left", "h":
+		m.panelIndex = max(0, m.panelIndex-1)
+	case "right", "l":
+		m.panelIndex = min(len(tags)-1, m.panelIndex+1)
+	case "up", "k":
+		m.moveTagRow(-1, index)
+	case "down", "j":
+		m.moveTagRow(1, index)
+	case " ":
+		if m.panelIndex < len(tags) {
+			m.tagFilter.toggle(tags[m.panelIndex].Tag)
+			m.applyTagFilter()
+		}
+	case "a":
+		m.tagFilter.Any = !m.tagFilter.Any
+		m.applyTagFilter()
+	case "x":
+		m.tagFilter.Tags = nil
+		m.applyTagFilter()
+	case "enter":
+		if !m.tagFilter.active() && m.panelIndex < len(tags) {
+			m.tagFilter.toggle(tags[m.panelIndex].Tag)
+			m.applyTagFilter()
+		}
+		m.setScreen(screenCatalog)
+		return m, nil
+	case "esc", "#":
+		m.setScreen(screenCatalog)
+		return m, nil
+	}
+	m.scrollTagCursor(index)
+	return m, nil
+}
+
+// scrollTagCursor keeps the cursor row visible. The cloud starts after
+// the two summary rows.
+func (m *Model) scrollTagCursor(index [][]int) {
+	visible := max(1, m.layout.ContentH-3)
+	for r, ids := range index {
+		for _, id := range ids {
+			if id != m.panelIndex {
+				continue
+			}
+			row := r + 2
+			if row < m.panelScroll {
+				m.panelScroll = max(0, row-2)
+			} else if row >= m.panelScroll+visible {
+				m.panelScroll = row - visible + 1
+			}
+		}
+	}
+}
+
+func (m *Model) viewTags() string {
+	tags := m.tagCounts()
+	cloud, _ := m.tagCloud(tags, m.tagCloudWidth())
+
+	mode := "AND"
+	if m.tagFilter.Any {
+		mode = "OR"
+	}
+	summary := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Italic(true).
+		Render("  Space selects tags; matching commands are listed across all categories")
+	if m.tagFilter.active() {
+		summary = fmt.Sprintf("  %s %s  %s",
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success)).Bold(true).Render("🏷  "+m.tagFilter.String()),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim)).Render("→"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text)).Render(fmt.Sprintf("%d commands", len(m.taggedCommands()))))
+	}
+
+	rows := append([]string{summary, ""}, cloud...)
+	if len(tags) == 0 {
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.textMuted)).
+			Italic(true).
+			Render("  No tags in the catalog"))
+	}
+
+	footer := fmt.Sprintf("%d tags • space select • a %s • x clear • enter show • esc back", len(tags), mode)
+	return m.renderPanel("🏷  Tags", "aurora", rows, -1, footer)
+}
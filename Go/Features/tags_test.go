@@ -0,0 +1,50 @@
+// tags_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagFilterMatches(t *testing.T) {
+	c := Command{Cmd: "gb", Tags: []string{"Git", "branch"}}
+	tests := []struct {
+		name string
+		f    tagFilter
+		want bool
+	}{
+		{"one tag", tagFilter{Tags: []string{"git"}}, true},
+		{"case-insensitive", tagFilter{Tags: []string{"BRANCH"}}, true},
+		{"all tags", tagFilter{Tags: []string{"git", "branch"}}, true},
+		{"missing tag", tagFilter{Tags: []string{"git", "docker"}}, false},
+		{"any with one match", tagFilter{Tags: []string{"git", "docker"}, Any: true}, true},
+		{"any with no match", tagFilter{Tags: []string{"docker", "net"}, Any: true}, false},
+		{"unknown tag", tagFilter{Tags: []string{"docker"}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.f.matches(c); got != tt.want {
+			t.Errorf("%s: matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if (tagFilter{Tags: []string{"git"}}).matches(Command{Cmd: "ls"}) {
+		t.Error("untagged command matched")
+	}
+}
+
+func TestTagFilterToggle(t *testing.T) {
+	var f tagFilter
+	f.toggle("git")
+	f.toggle("branch")
+	f.toggle("net")
+	f.toggle("branch")
+	if want := []string{"git", "net"}; !reflect.DeepEqual(f.Tags, want) {
+		t.Errorf("Tags = %v, want %v", f.Tags, want)
+	}
+	if got := f.String(); got != "git AND net" {
+		t.Errorf("String() = %q", got)
+	}
+	f.Any = true
+	if got := f.String(); got != "git OR net" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,311 @@
+// team.go
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         TEAM CATALOG
+// ══════════════════════════════════════════════════════════════════
+//
+// A team catalog is fetched from FEATURES_TEAM_URL and cached in the
+// state dir. It uses the same format as catalog.json and is merged
+// between the built-in data and the personal overlay, so precedence is:
+// built-in < team < personal.
+
+const (
+	teamDir          = "team"
+	teamCacheFile    = "catalog.json"
+	teamMetaFile     = "meta.json"
+	teamFetchTimeout = 10 * time.Second
+	teamMaxSize      = 4 << 20
+)
+
+// teamConfig is read from the environment.
+type teamConfig struct {
+	URL       string // catalog URL, empty disables the team catalog
+	SHA256    string // optional hex digest the body must match
+	PublicKey string // optional base64 ed25519 key; the signature is fetched from URL+".sig"
+}
+
+func teamConfigFromEnv() teamConfig {
+	return teamConfig{
+		URL:       strings.TrimSpace(os.Getenv("FEATURES_TEAM_URL")),
+		SHA256:    strings.ToLower(strings.TrimSpace(os.Getenv("FEATURES_TEAM_SHA256"))),
+		PublicKey: strings.TrimSpace(os.Getenv("FEATURES_TEAM_PUBKEY")),
+	}
+}
+
+// teamMeta is the validator data stored next to the cached body.
+type teamMeta struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Signature    string    `json:"signature,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+// teamCatalog is a verified team catalog and where it came from.
+type teamCatalog struct {
+	Name      string
+	Commands  []catalogEntry
+	FetchedAt time.Time
+	Offline   bool // served from cache after a failed fetch
+}
+
+// has reports whether the team catalog defines cmd in category catID.
+func (t teamCatalog) has(catID, cmd string) bool {
+	for _, e := range t.Commands {
+		if e.Category == catID && e.Cmd == cmd && !e.Deleted {
+			return true
+		}
+	}
+	return false
+}
+
+// teamLoadedMsg carries the result of a background fetch. Catalog is nil
+// when nothing changed.
+type teamLoadedMsg struct {
+	Catalog *teamCatalog
+	Status  string
+	Err     error
+}
+
+func teamPath(name string) string {
+	return filepath.Join(stateDir(), teamDir, name)
+}
+
+// verify checks body against the configured checksum and signature.
+func (c teamConfig) verify(body []byte, sig string) error {
+	if c.SHA256 != "" {
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != c.SHA256 {
+			return errors.New("checksum mismatch")
+		}
+	}
+	if c.PublicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(c.PublicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return errors.New("invalid FEATURES_TEAM_PUBKEY")
+		}
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sig))
+		if err != nil || !ed25519.Verify(ed25519.PublicKey(key), body, raw) {
+			return errors.New("bad signature")
+		}
+	}
+	return nil
+}
+
+func parseTeamCatalog(body []byte) (teamCatalog, error) {
+	f, err := parseCatalog(body)
+	if err != nil {
+		return teamCatalog{}, fmt.Errorf("invalid catalog: %w", err)
+	}
+	return teamCatalog{Name: f.Name, Commands: f.Commands}, nil
+}
+
+// loadTeamCache reads and re-verifies the cached catalog for c.URL.
+func loadTeamCache(c teamConfig) (teamCatalog, teamMeta, error) {
+	var meta teamMeta
+	if err := loadJSON(teamPath(teamMetaFile), &meta); err != nil {
+		return teamCatalog{}, meta, err
+	}
+	if meta.URL != c.URL {
+		return teamCatalog{}, teamMeta{}, os.ErrNotExist
+	}
+	body, err := os.ReadFile(teamPath(teamCacheFile))
+	if err != nil {
+		return teamCatalog{}, teamMeta{}, err
+	}
+	if err := c.verify(body, meta.Signature); err != nil {
+		return teamCatalog{}, teamMeta{}, fmt.Errorf("cached team catalog: %w", err)
+	}
+	tc, err := parseTeamCatalog(body)
+	tc.FetchedAt = meta.FetchedAt
+	return tc, meta, err
+}
+
+func saveTeamCache(body []byte, meta teamMeta) error {
+	path := teamPath(teamCacheFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", body, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	return saveJSON(teamPath(teamMetaFile), meta)
+}
+
+// fetchTeamCmd revalidates the cached catalog against the server. When
+// the server is unreachable the cache keeps being used. manual reports an
+// unchanged catalog too.
+func fetchTeamCmd(c teamConfig, manual bool) tea.Cmd {
+	return func() tea.Msg {
+		tc, meta, err := fetchTeam(c)
+		if err == nil {
+			return teamLoadedMsg{Catalog: tc, Status: fmt.Sprintf("Team catalog updated (%d commands)", len(tc.Commands))}
+		}
+		if errors.Is(err, errNotModified) {
+			if manual {
+				return teamLoadedMsg{Status: "Team catalog is up to date"}
+			}
+			return teamLoadedMsg{}
+		}
+		if !meta.FetchedAt.IsZero() {
+			return teamLoadedMsg{
+				Status: "Team catalog offline, using cache from " + meta.FetchedAt.Local().Format("Jan 2 15:04"),
+				Err:    err,
+			}
+		}
+		return teamLoadedMsg{Err: err}
+	}
+}
+
+var errNotModified = errors.New("not modified")
+
+func fetchTeam(c teamConfig) (*teamCatalog, teamMeta, error) {
+	_, meta, _ := loadTeamCache(c)
+
+	client := &http.Client{Timeout: teamFetchTimeout}
+	req, err := http.NewRequest(http.MethodGet, c.URL, nil)
+	if err != nil {
+		return nil, meta, err
+	}
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, meta, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && !meta.FetchedAt.IsZero():
+		meta.FetchedAt = time.Now()
+		_ = saveJSON(teamPath(teamMetaFile), meta)
+		return nil, meta, errNotModified
+	case resp.StatusCode != http.StatusOK:
+		return nil, meta, fmt.Errorf("team catalog: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, teamMaxSize+1))
+	if err != nil {
+		return nil, meta, err
+	}
+	if len(body) > teamMaxSize {
+		return nil, meta, errors.New("team catalog too large")
+	}
+
+	sig := ""
+	if c.PublicKey != "" {
+		if sig, err = fetchSignature(client, c.URL+".sig"); err != nil {
+			return nil, meta, err
+		}
+	}
+	if err := c.verify(body, sig); err != nil {
+		return nil, meta, fmt.Errorf("team catalog: %w", err)
+	}
+	tc, err := parseTeamCatalog(body)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	newMeta := teamMeta{
+		URL:          c.URL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Signature:    sig,
+		FetchedAt:    time.Now(),
+	}
+	if err := saveTeamCache(body, newMeta); err != nil {
+		return nil, meta, err
+	}
+	tc.FetchedAt = newMeta.FetchedAt
+	return &tc, newMeta, nil
+}
+
+func fetchSignature(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("team catalog signature: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return string(data), err
+}
+
+// handleTeamLoaded applies a background fetch result.
+func (m *Model) handleTeamLoaded(msg teamLoadedMsg) {
+	if msg.Catalog != nil {
+		m.team = *msg.Catalog
+		m.reloadCatalog()
+		m.calculateLayout()
+	}
+	m.team.Offline = msg.Err != nil && !m.team.FetchedAt.IsZero()
+	switch {
+	case msg.Status != "" && msg.Err != nil:
+		m.showToast(msg.Status, "warning")
+	case msg.Status != "":
+		m.showToast(msg.Status, "success")
+	case msg.Err != nil:
+		m.showToast(fmt.Sprintf("Team catalog: %v", msg.Err), "error")
+	}
+}
+
+// loadCachedTeam uses the cached team catalog until the background fetch
+// completes, so Features starts with it even when offline.
+func (m *Model) loadCachedTeam() {
+	if m.teamConfig.URL == "" {
+		return
+	}
+	tc, _, err := loadTeamCache(m.teamConfig)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		m.showToast(err.Error(), "error")
+		return
+	}
+	m.team = tc
+}
+
+// teamFetchCmd revalidates the team catalog at startup, or nil when none
+// is configured.
+func (m Model) teamFetchCmd() tea.Cmd {
+	if m.teamConfig.URL == "" {
+		return nil
+	}
+	return fetchTeamCmd(m.teamConfig, false)
+}
+
+// refreshTeam re-fetches the team catalog on demand.
+func (m *Model) refreshTeam() tea.Cmd {
+	if m.teamConfig.URL == "" {
+		m.showToast("No team catalog (set FEATURES_TEAM_URL)", "info")
+		return nil
+	}
+	m.showToast("Refreshing team catalog…", "info")
+	return fetchTeamCmd(m.teamConfig, true)
+}
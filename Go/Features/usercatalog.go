@@ -0,0 +1,518 @@
+// usercatalog.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         USER CATALOG OVERLAY
+// ══════════════════════════════════════════════════════════════════
+
+const userCatalogFile = "catalog.json"
+
+// catalogEntry is the on-disk form of a command placed in a category.
+type catalogEntry struct {
+	Category     string   `json:"category"`
+	CategoryName string   `json:"categoryName,omitempty"`
+	CategoryIcon string   `json:"categoryIcon,omitempty"`
+	Parent       string   `json:"parent,omitempty"` // parent ID for a new category
+	Cmd          string   `json:"cmd"`
+	Desc         string   `json:"desc,omitempty"`
+	Usage        string   `json:"usage,omitempty"`
+	Example      string   `json:"example,omitempty"`
+	Hot          string   `json:"hot,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Since        string   `json:"since,omitempty"`
+	Risk         string   `json:"risk,omitempty"`
+	Privilege    string   `json:"privilege,omitempty"`
+	Danger       bool     `json:"danger,omitempty"` // schema version 1 only, migrated on load
+	Platforms    []string `json:"platforms,omitempty"`
+	Deleted      bool     `json:"deleted,omitempty"`
+}
+
+// userCatalog is the personal overlay merged on top of the built-in data.
+type userCatalog struct {
+	Commands []catalogEntry `json:"commands"`
+}
+
+func loadUserCatalog() (userCatalog, error) {
+	data, err := os.ReadFile(statePath(userCatalogFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return userCatalog{}, nil
+	}
+	if err != nil {
+		return userCatalog{}, err
+	}
+	f, err := parseCatalog(data)
+	return userCatalog{Commands: f.Commands}, err
+}
+
+// save writes the overlay in the current schema version.
+func (uc userCatalog) save() error {
+	return saveJSON(statePath(userCatalogFile), catalogFile{
+		SchemaVersion: catalogSchemaVersion,
+		Commands:      uc.Commands,
+	})
+}
+
+func (e catalogEntry) command() Command {
+	return Command{
+		Cmd:       e.Cmd,
+		Desc:      e.Desc,
+		Usage:     e.Usage,
+		Example:   e.Example,
+		Hot:       e.Hot,
+		Tags:      e.Tags,
+		Since:     e.Since,
+		Risk:      e.Risk,
+		Privilege: e.Privilege,
+		Platforms: e.Platforms,
+	}
+}
+
+func entryFromCommand(catID, catName string, c Command) catalogEntry {
+	return catalogEntry{
+		Category:     catID,
+		CategoryName: catName,
+		Cmd:          c.Cmd,
+		Desc:         c.Desc,
+		Usage:        c.Usage,
+		Example:      c.Example,
+		Hot:          c.Hot,
+		Tags:         c.Tags,
+		Since:        c.Since,
+		Risk:         c.Risk,
+		Privilege:    c.Privilege,
+		Platforms:    c.Platforms,
+	}
+}
+
+// splitList splits a comma separated form value, dropping empty items.
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// indexOf returns the position of the entry for cmd in category catID.
+func (uc userCatalog) indexOf(catID, cmd string) int {
+	for i, e := range uc.Commands {
+		if e.Category == catID && e.Cmd == cmd {
+			return i
+		}
+	}
+	return -1
+}
+
+// remove drops the entry for cmd in category catID, if any.
+func (uc *userCatalog) remove(catID, cmd string) {
+	if i := uc.indexOf(catID, cmd); i >= 0 {
+		uc.Commands = append(uc.Commands[:i], uc.Commands[i+1:]...)
+	}
+}
+
+// put replaces or appends an entry.
+func (uc *userCatalog) put(e catalogEntry) {
+	if i := uc.indexOf(e.Category, e.Cmd); i >= 0 {
+		uc.Commands[i] = e
+		return
+	}
+	uc.Commands = append(uc.Commands, e)
+}
+
+// mergeEntries applies catalog entries onto cats. Entries replace commands
+// with the same name in the same category, tombstones remove them, and
+// unknown categories are created on the fly. Merged commands are tagged
+// with source.
+func mergeEntries(cats []Category, entries []catalogEntry, source string) []Category {
+	for _, e := range entries {
+		ci := categoryIndex(cats, e.Category)
+		if ci < 0 {
+			if e.Deleted {
+				continue
+			}
+			name := e.CategoryName
+			if name == "" {
+				name = e.Category
+			}
+			icon := e.CategoryIcon
+			if icon == "" {
+				icon = "⭐"
+			}
+			cats = append(cats, Category{
+				ID: e.Category, Name: name, Icon: icon, Gradient: "gold", Parent: e.Parent,
+			})
+			ci = len(cats) - 1
+		}
+
+		cmds := cats[ci].Commands
+		pos := -1
+		for i, c := range cmds {
+			if c.Cmd == e.Cmd {
+				pos = i
+				break
+			}
+		}
+
+		if e.Deleted {
+			if pos >= 0 {
+				cats[ci].Commands = append(cmds[:pos:pos], cmds[pos+1:]...)
+			}
+			continue
+		}
+
+		c := e.command()
+		c.Source = source
+		if pos >= 0 {
+			c.I18n = cmds[pos].I18n
+			cmds[pos] = c
+		} else {
+			cats[ci].Commands = append(cmds, c)
+		}
+	}
+	return cats
+}
+
+// categoryIndex finds a category by ID or (case-insensitive) name.
+func categoryIndex(cats []Category, key string) int {
+	for i, c := range cats {
+		if c.ID == key {
+			return i
+		}
+	}
+	// Names repeat between real categories and the generated Here ones
+	// ("Git" and Here › Git), so prefer the real one.
+	found := -1
+	for i, c := range cats {
+		if strings.EqualFold(c.Name, key) {
+			if !c.Virtual {
+				return i
+			}
+			if found < 0 {
+				found = i
+			}
+		}
+	}
+	return found
+}
+
+// slugify turns a category name into an ID.
+func slugify(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '_':
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         CATALOG ASSEMBLY
+// ══════════════════════════════════════════════════════════════════
+
+// reloadCatalog rebuilds m.categories from the built-in data and overlays,
+// keeping the current category selected where possible.
+func (m *Model) reloadCatalog() {
+	curID := ""
+	if m.catIndex < len(m.categories) {
+		curID = m.categories[m.catIndex].ID
+	}
+
+	cats := initData()
+	applyTranslations(cats)
+	cats = mergeEntries(cats, m.team.Commands, sourceTeam)
+	cats = m.mergePlugins(cats)
+	cats = mergeEntries(cats, m.userCatalog.Commands, sourceUser)
+	orderCategories(cats, m.catPrefs.Order)
+	m.categories = cats
+
+	m.totalCmds = 0
+	for _, cat := range m.categories {
+		m.totalCmds += len(cat.Commands)
+	}
+
+	// The Here view goes first and borrows the catalog's git commands
+	var gitCmds []Command
+	if gi := categoryIndex(cats, "git"); gi >= 0 {
+		gitCmds = m.categoryCommands(gi)
+	}
+	if here := m.here.hereCategories(gitCmds); len(here) > 0 {
+		m.categories = append(here, cats...)
+	}
+
+	m.catIndex = 0
+	if i := categoryIndex(m.categories, curID); i >= 0 {
+		m.catIndex = i
+	}
+	m.updateFiltered()
+	if m.itemIndex >= len(m.filtered) {
+		m.itemIndex = max(0, len(m.filtered)-1)
+	}
+	m.adjustScroll()
+}
+
+// isShared reports whether cmd comes from the built-in data, the team
+// catalog or a provider, so removing it from the personal overlay needs a
+// tombstone.
+func (m *Model) isShared(catID, cmd string) bool {
+	return isBuiltin(catID, cmd) || m.team.has(catID, cmd) || m.pluginHas(catID, cmd)
+}
+
+// isBuiltin reports whether cmd exists in the built-in category catID.
+func isBuiltin(catID, cmd string) bool {
+	for _, cat := range initData() {
+		if cat.ID != catID {
+			continue
+		}
+		for _, c := range cat.Commands {
+			if c.Cmd == cmd {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         ADD / EDIT / DELETE
+// ══════════════════════════════════════════════════════════════════
+
+// openCommandForm opens the add form, or the edit form when edit is set.
+func (m *Model) openCommandForm(edit bool) {
+	cat := m.categories[m.catIndex]
+	var c Command
+	title := "New Command"
+	if edit {
+		if m.itemIndex >= len(m.filtered) {
+			return
+		}
+		c = m.filtered[m.itemIndex]
+		cat = m.categories[m.ownerOf(c.Cmd)]
+		title = "Edit " + c.Cmd
+		if cat.Virtual {
+			m.showToast("Generated entries can't be edited", "warning")
+			return
+		}
+	}
+	catName := cat.Name
+	if cat.Virtual {
+		catName = ""
+	}
+
+	f := newInputForm(title, formCommand,
+		newFormField("Category", catName, "category id or name"),
+		newFormField("Command", c.Cmd, "e.g. git log --oneline -n {{count}}"),
+		newFormField("Description", c.Desc, "what it does"),
+		newFormField("Usage", c.Usage, "syntax or notes"),
+		newFormField("Example", c.Example, "example invocation"),
+		newFormField("Tags", strings.Join(c.Tags, ", "), "comma separated"),
+		newFormField("Platforms", strings.Join(c.Platforms, ", "), "windows, linux, macos, pwsh>=7 (empty: all)"),
+		newFormField("Risk", c.Risk, strings.Join(riskLevels, ", ")),
+		newFormField("Privilege", c.Privilege, strings.Join(privileges, ", ")),
+	)
+	if edit {
+		f.OrigCat = cat.ID
+		f.OrigCmd = c.Cmd
+		f.Target = c
+		f.focusField(1)
+	}
+	m.form = f
+}
+
+// saveCommandForm validates the command form and writes the overlay.
+func (m *Model) saveCommandForm() error {
+	f := m.form
+	catKey := f.value("Category")
+	cmd := f.value("Command")
+	desc := f.value("Description")
+	if catKey == "" {
+		return errors.New("category is required")
+	}
+	if cmd == "" {
+		return errors.New("command is required")
+	}
+	if desc == "" {
+		return errors.New("description is required")
+	}
+
+	catID, catName := slugify(catKey), catKey
+	if ci := categoryIndex(m.categories, catKey); ci >= 0 {
+		catID, catName = m.categories[ci].ID, m.categories[ci].Name
+	}
+	if catID == "" {
+		return errors.New("category name needs letters or digits")
+	}
+
+	risk := strings.ToLower(f.value("Risk"))
+	if !validLevel(riskLevels, risk) {
+		return fmt.Errorf("risk must be one of %s", strings.Join(riskLevels, ", "))
+	}
+	priv := strings.ToLower(f.value("Privilege"))
+	if !validLevel(privileges, priv) {
+		return fmt.Errorf("privilege must be one of %s", strings.Join(privileges, ", "))
+	}
+
+	tags := splitList(f.value("Tags"))
+	platforms := splitList(strings.ToLower(f.value("Platforms")))
+	for _, p := range platforms {
+		if !validPlatform(p) {
+			return fmt.Errorf("unknown platform %q", p)
+		}
+	}
+
+	// A new entry, or an edit that renames or moves one, must not collide
+	// with a command already in the target category.
+	renamed := f.OrigCmd != "" && (f.OrigCat != catID || f.OrigCmd != cmd)
+	if ci := categoryIndex(m.categories, catID); ci >= 0 && (f.OrigCmd == "" || renamed) {
+		for _, c := range m.categories[ci].Commands {
+			if c.Cmd == cmd {
+				return fmt.Errorf("%q already exists in %s", cmd, catName)
+			}
+		}
+	}
+
+	uc := m.userCatalog
+	uc.Commands = append([]catalogEntry(nil), uc.Commands...)
+
+	if renamed {
+		// Renamed or moved: drop the old overlay entry and hide the original.
+		uc.remove(f.OrigCat, f.OrigCmd)
+		if m.isShared(f.OrigCat, f.OrigCmd) {
+			uc.put(catalogEntry{Category: f.OrigCat, Cmd: f.OrigCmd, Deleted: true})
+		}
+	}
+
+	var base Command
+	if f.OrigCmd != "" {
+		base = f.Target
+	}
+	base.Cmd, base.Desc, base.Tags, base.Platforms = cmd, desc, tags, platforms
+	base.Risk, base.Privilege = risk, priv
+	base.Usage, base.Example = f.value("Usage"), f.value("Example")
+	uc.put(entryFromCommand(catID, catName, base))
+
+	if err := uc.save(); err != nil {
+		return fmt.Errorf("save failed: %v", err)
+	}
+	m.userCatalog = uc
+	m.reloadCatalog()
+	m.selectCommand(catID, cmd)
+	m.showToast(fmt.Sprintf("Saved: %s", cmd), "success")
+	return nil
+}
+
+// deleteSelected removes the selected command via the overlay.
+func (m *Model) deleteSelected() {
+	if m.itemIndex >= len(m.filtered) {
+		return
+	}
+	c := m.filtered[m.itemIndex]
+	cat := m.categories[m.ownerOf(c.Cmd)]
+	if cat.Virtual {
+		m.showToast("Generated entries can't be deleted", "warning")
+		return
+	}
+
+	uc := m.userCatalog
+	uc.Commands = append([]catalogEntry(nil), uc.Commands...)
+	uc.remove(cat.ID, c.Cmd)
+	if m.isShared(cat.ID, c.Cmd) {
+		uc.put(catalogEntry{Category: cat.ID, Cmd: c.Cmd, Deleted: true})
+	}
+
+	if err := uc.save(); err != nil {
+		m.showToast(fmt.Sprintf("Delete failed: %v", err), "error")
+		return
+	}
+	m.userCatalog = uc
+	m.reloadCatalog()
+	m.showToast(fmt.Sprintf("Deleted: %s", c.Cmd), "warning")
+}
+
+// selectCommand moves the selection to cmd in category catID.
+func (m *Model) selectCommand(catID, cmd string) {
+	ci := categoryIndex(m.categories, catID)
+	if ci < 0 {
+		return
+	}
+	m.catIndex = ci
+	m.searchInput.Reset()
+	m.updateFiltered()
+	for i, c := range m.filtered {
+		if c.Cmd == cmd {
+			m.itemIndex = i
+			break
+		}
+	}
+	m.adjustScroll()
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         SNIPPET PLACEHOLDERS
+// ══════════════════════════════════════════════════════════════════
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// placeholders returns the distinct {{name}} placeholders in s, in order.
+func placeholders(s string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderRe.FindAllStringSubmatch(s, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// fillPlaceholders substitutes {{name}} placeholders with values.
+func fillPlaceholders(s string, values map[string]string) string {
+	return placeholderRe.ReplaceAllStringFunc(s, func(tok string) string {
+		name := placeholderRe.FindStringSubmatch(tok)[1]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		return tok
+	})
+}
+
+// openPlaceholderForm asks for snippet values before copying c.
+func (m *Model) openPlaceholderForm(c Command) {
+	var fields []formField
+	for _, name := range placeholders(c.Cmd) {
+		fields = append(fields, newFormField(name, "", "value for {{"+name+"}}"))
+	}
+	f := newInputForm("Fill "+c.Cmd, formPlaceholder, fields...)
+	f.Target = c
+	m.form = f
+}
+
+func (m *Model) submitPlaceholderForm() error {
+	m.copyText(m.placeholderLine(), m.form.Target.Cmd)
+	return nil
+}
+
+// placeholderLine fills the form target's placeholders with the entered
+// values.
+func (m *Model) placeholderLine() string {
+	values := make(map[string]string)
+	for _, fld := range m.form.Fields {
+		values[fld.Label] = strings.TrimSpace(fld.Input.Value())
+	}
+	return fillPlaceholders(m.form.Target.Cmd, values)
+}
@@ -0,0 +1,81 @@
+// usercatalog_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"git log -n {{count}}", []string{"count"}},
+		{"cp {{ src }} {{dst}} && ls {{src}}", []string{"src", "dst"}},
+		{"echo {{}} {{a b}}", nil},
+		{"no placeholders", nil},
+	}
+	for _, tt := range tests {
+		if got := placeholders(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("placeholders(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	values := map[string]string{"src": "a.txt", "dst": "b.txt", "empty": ""}
+	tests := []struct {
+		in, want string
+	}{
+		{"cp {{src}} {{dst}}", "cp a.txt b.txt"},
+		{"cp {{ src }} {{src}}", "cp a.txt a.txt"},
+		{"echo {{missing}}", "echo {{missing}}"},
+		{"echo [{{empty}}]", "echo []"},
+		{"echo {{src}}{{dst}}", "echo a.txtb.txt"},
+	}
+	for _, tt := range tests {
+		if got := fillPlaceholders(tt.in, values); got != tt.want {
+			t.Errorf("fillPlaceholders(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Dev Tools", "dev-tools"},
+		{"  Git ", "git"},
+	}
+	for _, tt := range tests {
+		if got := slugify(tt.in); got != tt.want {
+			t.Errorf("slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryIndex(t *testing.T) {
+	cats := []Category{
+		{ID: "here-git", Name: "Git", Virtual: true},
+		{ID: "git", Name: "Git"},
+		{ID: "dev", Name: "Dev Tools"},
+		{ID: "here-dev", Name: "Build", Virtual: true},
+	}
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"git", 1},
+		{"here-git", 0},
+		{"Git", 1},
+		{"dev tools", 2},
+		{"build", 3},
+		{"nope", -1},
+	}
+	for _, tt := range tests {
+		if got := categoryIndex(cats, tt.key); got != tt.want {
+			t.Errorf("categoryIndex(%q) = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
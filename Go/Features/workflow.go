@@ -0,0 +1,474 @@
+// workflow.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         WORKFLOWS
+// ══════════════════════════════════════════════════════════════════
+
+const workflowsFile = "workflows.json"
+
+// workflowNameRe limits names to what is safe both as a PowerShell function
+// name and as the export file name.
+var workflowNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Workflow is a named sequence of command lines. Steps may contain
+// {{name}} placeholders that are asked for once before running.
+type Workflow struct {
+	Name  string   `json:"name"`
+	Desc  string   `json:"desc,omitempty"`
+	Steps []string `json:"steps"`
+}
+
+type workflowStore struct {
+	Workflows []Workflow `json:"workflows"`
+}
+
+func loadWorkflows() ([]Workflow, error) {
+	var ws workflowStore
+	err := loadJSON(statePath(workflowsFile), &ws)
+	return ws.Workflows, err
+}
+
+func saveWorkflows(wfs []Workflow) error {
+	return saveJSON(statePath(workflowsFile), workflowStore{Workflows: wfs})
+}
+
+// placeholders returns the distinct placeholders across all steps.
+func (w Workflow) placeholders() []string {
+	return placeholders(strings.Join(w.Steps, "\n"))
+}
+
+// Step run states
+const (
+	stepPending = ""
+	stepDone    = "done"
+	stepFailed  = "failed"
+	stepSkipped = "skipped"
+)
+
+// workflowRun tracks a step-by-step execution.
+type workflowRun struct {
+	Workflow Workflow
+	Steps    []string // steps with placeholders filled
+	Status   []string
+	Current  int
+	Waiting  bool // a step is executing
+}
+
+func (r *workflowRun) finished() bool {
+	return r.Current >= len(r.Steps)
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         WORKFLOW ACTIONS
+// ══════════════════════════════════════════════════════════════════
+
+// openWorkflowForm opens the editor for wf; an empty Name means a new one.
+// Each step gets a field of its own, so a step may contain ";".
+func (m *Model) openWorkflowForm(wf Workflow) {
+	title := "New Workflow"
+	if wf.Name != "" {
+		title = "Edit " + wf.Name
+	}
+	fields := []formField{
+		newFormField("Name", wf.Name, "e.g. release"),
+		newFormField("Description", wf.Desc, "what it does"),
+	}
+	for i, s := range wf.Steps {
+		fields = append(fields, newStepField(i, s))
+	}
+	f := newInputForm(title, formWorkflow, append(fields, newStepField(len(wf.Steps), ""))...)
+	f.OrigCmd = wf.Name
+	m.form = f
+}
+
+const stepLabel = "Step "
+
+func newStepField(i int, value string) formField {
+	placeholder := "gc -m {{message}}"
+	if i > 0 {
+		placeholder = "next step, or leave empty"
+	}
+	return newFormField(fmt.Sprintf("%s%d", stepLabel, i+1), value, placeholder)
+}
+
+// growSteps keeps an empty step field at the end of the workflow form, so
+// there is always room for one more step.
+func (f *inputForm) growSteps() {
+	n := 0
+	for _, fld := range f.Fields {
+		if strings.HasPrefix(fld.Label, stepLabel) {
+			n++
+		}
+	}
+	if last := f.Fields[len(f.Fields)-1]; n > 0 && strings.TrimSpace(last.Input.Value()) != "" {
+		f.Fields = append(f.Fields, newStepField(n, ""))
+	}
+}
+
+// steps returns the non-empty step fields of the workflow form.
+func (f *inputForm) steps() []string {
+	var steps []string
+	for _, fld := range f.Fields {
+		if s := strings.TrimSpace(fld.Input.Value()); strings.HasPrefix(fld.Label, stepLabel) && s != "" {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+func (m *Model) saveWorkflowForm() error {
+	f := m.form
+	name := f.value("Name")
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if !workflowNameRe.MatchString(name) {
+		return errors.New("name may only use letters, digits, - and _")
+	}
+	steps := f.steps()
+	if len(steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+	if err := checkWorkflowParams(Workflow{Steps: steps}); err != nil {
+		return err
+	}
+
+	wfs := make([]Workflow, 0, len(m.workflows)+1)
+	for _, w := range m.workflows {
+		if w.Name == name && w.Name != f.OrigCmd {
+			return fmt.Errorf("workflow %q already exists", name)
+		}
+		if w.Name != f.OrigCmd {
+			wfs = append(wfs, w)
+		}
+	}
+	wfs = append(wfs, Workflow{Name: name, Desc: f.value("Description"), Steps: steps})
+
+	if err := saveWorkflows(wfs); err != nil {
+		return fmt.Errorf("save failed: %v", err)
+	}
+	m.workflows = wfs
+	m.screen = screenWorkflows
+	m.panelIndex = len(wfs) - 1
+	m.showToast(fmt.Sprintf("Saved workflow: %s", name), "success")
+	return nil
+}
+
+func (m *Model) deleteSelectedWorkflow() {
+	if m.panelIndex >= len(m.workflows) {
+		return
+	}
+	name := m.workflows[m.panelIndex].Name
+	wfs := append(m.workflows[:m.panelIndex:m.panelIndex], m.workflows[m.panelIndex+1:]...)
+	if err := saveWorkflows(wfs); err != nil {
+		m.showToast(fmt.Sprintf("Delete failed: %v", err), "error")
+		return
+	}
+	m.workflows = wfs
+	m.movePanel(0, len(wfs))
+	m.showToast(fmt.Sprintf("Deleted workflow: %s", name), "warning")
+}
+
+// startWorkflow asks for placeholder values (if any) and opens the runner.
+func (m *Model) startWorkflow(wf Workflow) {
+	names := wf.placeholders()
+	if len(names) == 0 {
+		m.beginRun(wf, nil)
+		return
+	}
+	var fields []formField
+	for _, name := range names {
+		fields = append(fields, newFormField(name, "", "value for {{"+name+"}}"))
+	}
+	f := newInputForm("Run "+wf.Name, formWorkflowRun, fields...)
+	f.Workflow = wf
+	m.form = f
+}
+
+func (m *Model) submitWorkflowRunForm() error {
+	values := make(map[string]string)
+	for _, fld := range m.form.Fields {
+		values[fld.Label] = strings.TrimSpace(fld.Input.Value())
+	}
+	m.beginRun(m.form.Workflow, values)
+	return nil
+}
+
+func (m *Model) beginRun(wf Workflow, values map[string]string) {
+	steps := make([]string, len(wf.Steps))
+	for i, s := range wf.Steps {
+		steps[i] = fillPlaceholders(s, values)
+	}
+	m.run = &workflowRun{
+		Workflow: wf,
+		Steps:    steps,
+		Status:   make([]string, len(steps)),
+	}
+	m.screen = screenRunner
+}
+
+// workflowStepDone records the result of the running step and pauses
+// before the next one.
+func (m *Model) workflowStepDone(err error) {
+	r := m.run
+	r.Waiting = false
+	if err != nil {
+		r.Status[r.Current] = stepFailed
+		m.showToast(fmt.Sprintf("Step %d failed: %v", r.Current+1, err), "error")
+		return
+	}
+	r.Status[r.Current] = stepDone
+	r.Current++
+	if r.finished() {
+		m.showToast(fmt.Sprintf("Workflow %s finished", r.Workflow.Name), "success")
+	}
+}
+
+// exportWorkflow renders wf as a PowerShell function; placeholders become
+// mandatory parameters and the function stops at the first failing step.
+func exportWorkflow(wf Workflow) string {
+	var b strings.Builder
+	if wf.Desc != "" {
+		fmt.Fprintf(&b, "# %s\n", wf.Desc)
+	}
+	fmt.Fprintf(&b, "function global:%s {\n", wf.Name)
+	if names := wf.placeholders(); len(names) > 0 {
+		b.WriteString("    param(\n")
+		for i, n := range names {
+			sep := ","
+			if i == len(names)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(&b, "        [Parameter(Mandatory)] [string]$%s%s\n", psIdent(n), sep)
+		}
+		b.WriteString("    )\n")
+	}
+	vars := make(map[string]string)
+	for _, n := range wf.placeholders() {
+		vars[n] = "$" + psIdent(n)
+	}
+	for i, s := range wf.Steps {
+		fmt.Fprintf(&b, "    %s\n", fillPlaceholders(s, vars))
+		if i < len(wf.Steps)-1 {
+			b.WriteString("    if (-not $?) { return }\n")
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// psIdent makes a placeholder name usable as a PowerShell variable.
+func psIdent(s string) string {
+	return strings.ReplaceAll(s, "-", "_")
+}
+
+// psAutomaticVars are PowerShell's automatic variables (lower-case),
+// which a parameter must not shadow.
+var psAutomaticVars = map[string]bool{
+	"_": true, "args": true, "consolefilename": true, "enabledexperimentalfeatures": true,
+	"error": true, "event": true, "eventargs": true, "eventsubscriber": true,
+	"executioncontext": true, "false": true, "foreach": true, "home": true, "host": true,
+	"input": true, "iscoreclr": true, "islinux": true, "ismacos": true, "iswindows": true,
+	"lastexitcode": true, "matches": true, "myinvocation": true, "nestedpromptlevel": true,
+	"null": true, "ofs": true, "pid": true, "profile": true, "psboundparameters": true,
+	"pscmdlet": true, "pscommandpath": true, "psculture": true, "psdebugcontext": true,
+	"psedition": true, "pshome": true, "psitem": true, "psscriptroot": true,
+	"pssenderinfo": true, "psuiculture": true, "psversiontable": true, "pwd": true,
+	"sender": true, "shellid": true, "stacktrace": true, "switch": true, "this": true,
+	"true": true,
+}
+
+// checkWorkflowParams rejects placeholders that would not work as the
+// parameters of the exported function: names that become the same
+// variable (PowerShell ignores case, and - turns into _) and names of
+// automatic or preference variables.
+func checkWorkflowParams(wf Workflow) error {
+	seen := make(map[string]string)
+	for _, n := range wf.placeholders() {
+		v := strings.ToLower(psIdent(n))
+		if psAutomaticVars[v] || strings.HasSuffix(v, "preference") {
+			return fmt.Errorf("{{%s}} is a reserved PowerShell variable, pick another name", n)
+		}
+		if other, ok := seen[v]; ok {
+			return fmt.Errorf("{{%s}} and {{%s}} would both become $%s", other, n, psIdent(n))
+		}
+		seen[v] = n
+	}
+	return nil
+}
+
+// exportSelectedWorkflow copies the function to the clipboard and writes
+// it under the state dir.
+func (m *Model) exportSelectedWorkflow() {
+	if m.panelIndex >= len(m.workflows) {
+		return
+	}
+	wf := m.workflows[m.panelIndex]
+	if !workflowNameRe.MatchString(wf.Name) {
+		// Written by hand into workflows.json
+		m.showToast(fmt.Sprintf("Export failed: %q is not a valid name", wf.Name), "error")
+		return
+	}
+	if err := checkWorkflowParams(wf); err != nil {
+		m.showToast(fmt.Sprintf("Export failed: %v", err), "error")
+		return
+	}
+	src := exportWorkflow(wf)
+
+	path := filepath.Join(stateDir(), "export", wf.Name+".ps1")
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, []byte(src), 0o644)
+	}
+	if err != nil {
+		m.showToast(fmt.Sprintf("Export failed: %v", err), "error")
+		return
+	}
+	if clipboard.WriteAll(src) != nil {
+		m.showToast(fmt.Sprintf("Exported to %s", path), "success")
+		return
+	}
+	m.showToast(fmt.Sprintf("Exported to %s (copied)", path), "success")
+}
+
+// ══════════════════════════════════════════════════════════════════
+//                         WORKFLOW SCREENS
+// ══════════════════════════════════════════════════════════════════
+
+func (m Model) handleWorkflowsKey(key string) (tea.Model, tea.Cmd) {
+	n := len(m.workflows)
+	switch key {
+	case "up", "k":
+		m.movePanel(-1, n)
+	case "down", "j":
+		m.movePanel(1, n)
+	case "enter", "r":
+		if m.panelIndex < n {
+			m.startWorkflow(m.workflows[m.panelIndex])
+			return m, textinput.Blink
+		}
+	case "n":
+		m.openWorkflowForm(Workflow{})
+		return m, textinput.Blink
+	case "e":
+		if m.panelIndex < n {
+			m.openWorkflowForm(m.workflows[m.panelIndex])
+			return m, textinput.Blink
+		}
+	case "D", "delete":
+		if m.panelIndex < n {
+			m.askConfirm(fmt.Sprintf("Delete workflow %s?", m.workflows[m.panelIndex].Name),
+				(*Model).deleteSelectedWorkflow)
+		}
+	case "x":
+		m.exportSelectedWorkflow()
+	case "esc", "W":
+		m.setScreen(screenCatalog)
+	}
+	return m, nil
+}
+
+func (m *Model) viewWorkflows() string {
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true).Width(16)
+	stepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success))
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textDim))
+	sep := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Render(" › ")
+
+	rows := make([]string, 0, len(m.workflows))
+	for _, w := range m.workflows {
+		desc := ""
+		if w.Desc != "" {
+			desc = "  " + descStyle.Render(w.Desc)
+		}
+		rows = append(rows, fmt.Sprintf(" ⚙ %s%s%s", nameStyle.Render(w.Name),
+			stepStyle.Render(strings.Join(w.Steps, sep)), desc))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colors.textMuted)).
+			Italic(true).
+			Render("  No workflows yet — press n, or s in the composer"))
+	}
+
+	footer := "enter run • n new • e edit • D delete • x export • esc back"
+	return m.renderPanel("⚙️ Workflows", "cosmic", rows, m.panelIndex, footer)
+}
+
+func (m Model) handleRunnerKey(key string) (tea.Model, tea.Cmd) {
+	r := m.run
+	if r == nil || r.Waiting {
+		return m, nil
+	}
+	switch key {
+	case "enter", "y":
+		if !r.finished() {
+			r.Waiting = true
+			return m, runLine(r.Steps[r.Current])
+		}
+		m.run = nil
+		m.setScreen(screenWorkflows)
+	case "s":
+		if !r.finished() {
+			r.Status[r.Current] = stepSkipped
+			r.Current++
+		}
+	case "esc":
+		if !r.finished() {
+			m.showToast(fmt.Sprintf("Workflow %s aborted", r.Workflow.Name), "warning")
+		}
+		m.run = nil
+		m.screen = screenWorkflows
+	}
+	return m, nil
+}
+
+func (m *Model) viewRunner() string {
+	r := m.run
+	if r == nil {
+		return m.viewWorkflows()
+	}
+
+	marks := map[string]string{
+		stepPending: "○",
+		stepDone:    lipgloss.NewStyle().Foreground(lipgloss.Color(colors.success)).Render("✓"),
+		stepFailed:  lipgloss.NewStyle().Foreground(lipgloss.Color(colors.error)).Render("✗"),
+		stepSkipped: lipgloss.NewStyle().Foreground(lipgloss.Color(colors.textMuted)).Render("↷"),
+	}
+	stepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.text))
+	curStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.primary)).Bold(true)
+
+	rows := make([]string, 0, len(r.Steps)+2)
+	for i, s := range r.Steps {
+		mark, style := marks[r.Status[i]], stepStyle
+		if i == r.Current {
+			mark, style = spinners[m.frame%len(spinners)], curStyle
+		}
+		rows = append(rows, fmt.Sprintf(" %s %2d. %s", mark, i+1, style.Render(s)))
+	}
+
+	footer := "enter run step • s skip • esc abort"
+	switch {
+	case r.Waiting:
+		footer = "running…"
+	case r.finished():
+		footer = "finished • enter close"
+	case r.Current < len(r.Status) && r.Status[r.Current] == stepFailed:
+		footer = "step failed • enter retry • s skip • esc abort"
+	}
+	return m.renderPanel("▶ "+r.Workflow.Name, "cosmic", rows, r.Current, footer)
+}
@@ -0,0 +1,113 @@
+// workflow_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSaveWorkflowFormName(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"release", true},
+		{"deploy-prod_2", true},
+		{"", false},
+		{"two words", false},
+		{"../../x", false},
+		{"a;b", false},
+		{"x{y}", false},
+		{"$(evil)", false},
+		{"sub/dir", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FEATURES_HOME", t.TempDir())
+			var m Model
+			m.config = defaultConfig()
+			m.form = newInputForm("Workflow", formWorkflow,
+				newFormField("Name", tt.name, ""),
+				newFormField("Description", "", ""),
+				newStepField(0, "gs"),
+				newStepField(1, "gp"),
+			)
+			err := m.saveWorkflowForm()
+			if (err == nil) != tt.ok {
+				t.Errorf("saveWorkflowForm(%q) error = %v, want ok %v", tt.name, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestExportWorkflow(t *testing.T) {
+	wf := Workflow{
+		Name:  "release",
+		Desc:  "Tag and push",
+		Steps: []string{"git tag {{version}}", "git push origin {{version}}"},
+	}
+	want := "# Tag and push\n" +
+		"function global:release {\n" +
+		"    param(\n" +
+		"        [Parameter(Mandatory)] [string]$version\n" +
+		"    )\n" +
+		"    git tag $version\n" +
+		"    if (-not $?) { return }\n" +
+		"    git push origin $version\n" +
+		"}\n"
+	if got := exportWorkflow(wf); got != want {
+		t.Errorf("exportWorkflow() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWorkflowFormSteps(t *testing.T) {
+	t.Setenv("FEATURES_HOME", t.TempDir())
+	m := Model{config: defaultConfig()}
+	m.openWorkflowForm(Workflow{Name: "release", Steps: []string{`Write-Host "a;b"; gs`}})
+	if n := len(m.form.Fields); n != 4 {
+		t.Fatalf("form has %d fields, want name, description, the step and an empty one", n)
+	}
+
+	// Typing into the empty last step adds another one
+	m.form.focusField(3)
+	for _, r := range "gp" {
+		mm, _ := m.handleFormKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = mm.(Model)
+	}
+	if n := len(m.form.Fields); n != 5 || m.form.Fields[4].Label != "Step 3" {
+		t.Fatalf("form has %d fields after typing a step", n)
+	}
+
+	if err := m.saveWorkflowForm(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`Write-Host "a;b"; gs`, "gp"}
+	if got := m.workflows[0].Steps; !reflect.DeepEqual(got, want) {
+		t.Errorf("Steps = %q, want %q", got, want)
+	}
+}
+
+func TestCheckWorkflowParams(t *testing.T) {
+	tests := []struct {
+		steps string
+		ok    bool
+	}{
+		{"git tag {{version}} ; git push {{remote}} {{version}}", true},
+		{"echo {{a-b}} {{a_b}}", false},
+		{"echo {{Name}} {{name}}", false},
+		{"echo {{host}}", false},
+		{"echo {{ARGS}}", false},
+		{"echo {{_}}", false},
+		{"echo {{input}}", false},
+		{"echo {{ErrorActionPreference}}", false},
+		{"echo {{hostname}}", true},
+	}
+	for _, tt := range tests {
+		err := checkWorkflowParams(Workflow{Steps: []string{tt.steps}})
+		if (err == nil) != tt.ok {
+			t.Errorf("checkWorkflowParams(%q) = %v, want ok %v", tt.steps, err, tt.ok)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"os"
@@ -21,6 +22,7 @@ import (
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
+	"termshot"
 )
 
 // ══════════════════════════════════════════════════════════════════
@@ -3140,13 +3142,24 @@ func (m Model) renderSystemDetails() string {
 // ══════════════════════════════════════════════════════════════════
 
 func main() {
-	p := tea.NewProgram(
-		initialModel(),
+	if len(os.Args) > 1 && os.Args[1] == "screenshot" {
+		if err := runScreenshot(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var sess termshot.Options
+	fs := flag.NewFlagSet("sysinfo-tui", flag.ExitOnError)
+	sess.Register(fs)
+	fs.Parse(os.Args[1:])
+
+	err := runSession(initialModel(), sess,
 		tea.WithAltScreen(),
 		tea.WithMouseAllMotion(),
 	)
-
-	if _, err := p.Run(); err != nil {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
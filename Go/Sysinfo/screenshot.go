@@ -0,0 +1,90 @@
+// screenshot.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"termshot"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                    SCREENSHOTS
+// ══════════════════════════════════════════════════════════════════
+//
+// `sysinfo-tui screenshot` collects the system info once, renders View()
+// without a terminal and converts the ANSI output into SVG or HTML.
+
+// runScreenshot renders one frame of the dashboard to --out.
+func runScreenshot(args []string) error {
+	fs := flag.NewFlagSet("screenshot", flag.ContinueOnError)
+	width := fs.Int("width", 140, "terminal width in cells")
+	height := fs.Int("height", 45, "terminal height in cells")
+	out := fs.String("out", "screenshot.svg", "output file, .svg or .html")
+	tab := fs.String("tab", "", "tab ID to show, e.g. cpu")
+	theme := fs.String("theme", "", "theme name, e.g. \"Ocean Depths\"")
+	keys := fs.String("keys", "", "space-separated keys to press first, e.g. \"down down\"")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *width < 60 || *height < 20 {
+		return errors.New("screenshot needs at least 60x20 cells")
+	}
+	write, err := termshot.Writer(*out)
+	if err != nil {
+		return err
+	}
+
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	lipgloss.SetHasDarkBackground(true)
+
+	m := initialModel()
+	m.Update(tea.WindowSizeMsg{Width: *width, Height: *height})
+	m.Update(sysInfoMsg(collectSystemInfo()))
+	if *tab != "" {
+		found := false
+		for i, t := range m.tabs {
+			if t.ID == *tab {
+				m.activeTab, found = i, true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown tab %q", *tab)
+		}
+	}
+	if *theme != "" {
+		found := false
+		for i, name := range m.themeNames {
+			if strings.EqualFold(name, *theme) {
+				m.themeIndex, found = i, true
+				currentTheme = themes[name]
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown theme %q", *theme)
+		}
+	}
+	for _, k := range strings.Fields(*keys) {
+		for _, key := range termshot.ParseKey(k, knownKey) {
+			m.Update(keyMsg(key))
+		}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	write(f, termshot.Cells(m.View(), *width, *height), shotPage())
+	return f.Close()
+}
+
+// shotPage is the page screenshots are drawn on, in the current theme.
+func shotPage() termshot.Page {
+	return termshot.Page{Title: "System Info", Bg: currentTheme.Background, Fg: currentTheme.Text}
+}
@@ -0,0 +1,212 @@
+// cells.go
+
+// Package termshot turns the ANSI output of a terminal UI into SVG or HTML
+// pictures and reads the session scripts that drive one. It is shared by
+// Features and Sysinfo, which pin different Bubble Tea versions, so it
+// only deals in strings and plain values.
+package termshot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         ANSI TO CELLS
+// ══════════════════════════════════════════════════════════════════
+
+// Style is the SGR state a cell was printed with. Colors are "#rrggbb",
+// "" for the default.
+type Style struct {
+	fg, bg                                          string
+	bold, faint, italic, underline, strike, reverse bool
+}
+
+// Cell is one grapheme cluster on screen; wide clusters such as emoji have
+// Width 2 and take two columns.
+type Cell struct {
+	Text  string
+	Width int
+	Style Style
+}
+
+// Cells splits ANSI output into rows of cells, cut and padded to
+// width x height.
+func Cells(s string, width, height int) [][]Cell {
+	var st Style
+	lines := strings.Split(s, "\n")
+	rows := make([][]Cell, height)
+	for y := range rows {
+		var row []Cell
+		col := 0
+		if y < len(lines) {
+			row, col = parseLine(lines[y], width, &st)
+		}
+		for ; col < width; col++ {
+			row = append(row, Cell{Text: " ", Width: 1})
+		}
+		rows[y] = row
+	}
+	return rows
+}
+
+// parseLine reads one line of ANSI output, updating st with each SGR
+// sequence. It returns the cells and the columns they take; text past
+// width is dropped.
+func parseLine(line string, width int, st *Style) ([]Cell, int) {
+	var row []Cell
+	col, state, full := 0, -1, false
+	for len(line) > 0 {
+		if line[0] == '\x1b' {
+			line, state = skipEscape(line, st), -1
+			continue
+		}
+		var cluster string
+		var w int
+		cluster, line, w, state = uniseg.FirstGraphemeClusterInString(line, state)
+		switch {
+		case cluster == "\t":
+			cluster, w = " ", 1
+		case cluster[0] < ' ' || cluster == "\x7f":
+			continue
+		case w == 0:
+			if len(row) > 0 && !full {
+				row[len(row)-1].Text += cluster
+			}
+			continue
+		}
+		if full = full || col+w > width; full {
+			continue
+		}
+		row = append(row, Cell{Text: cluster, Width: w, Style: *st})
+		col += w
+	}
+	return row, col
+}
+
+// skipEscape consumes the escape sequence at the start of s, applying it
+// to st when it sets graphic attributes.
+func skipEscape(s string, st *Style) string {
+	if len(s) < 2 {
+		return ""
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				if c == 'm' {
+					st.apply(s[2:i])
+				}
+				return s[i+1:]
+			}
+		}
+		return ""
+	case ']', 'P', '_':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return s[i+1:]
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return s[i+2:]
+			}
+		}
+		return ""
+	}
+	return s[2:]
+}
+
+// apply updates the style with the parameters of an SGR sequence.
+func (st *Style) apply(params string) {
+	var p []int
+	for _, f := range strings.FieldsFunc(params+";", func(r rune) bool { return r == ';' || r == ':' }) {
+		n, _ := strconv.Atoi(f)
+		p = append(p, n)
+	}
+	if len(p) == 0 {
+		p = []int{0}
+	}
+	for i := 0; i < len(p); i++ {
+		switch n := p[i]; {
+		case n == 0:
+			*st = Style{}
+		case n == 1:
+			st.bold = true
+		case n == 2:
+			st.faint = true
+		case n == 3:
+			st.italic = true
+		case n == 4:
+			st.underline = true
+		case n == 7:
+			st.reverse = true
+		case n == 9:
+			st.strike = true
+		case n == 22:
+			st.bold, st.faint = false, false
+		case n == 23:
+			st.italic = false
+		case n == 24:
+			st.underline = false
+		case n == 27:
+			st.reverse = false
+		case n == 29:
+			st.strike = false
+		case n >= 30 && n <= 37:
+			st.fg = ansiColor(n - 30)
+		case n >= 90 && n <= 97:
+			st.fg = ansiColor(n - 90 + 8)
+		case n >= 40 && n <= 47:
+			st.bg = ansiColor(n - 40)
+		case n >= 100 && n <= 107:
+			st.bg = ansiColor(n - 100 + 8)
+		case n == 39:
+			st.fg = ""
+		case n == 49:
+			st.bg = ""
+		case n == 38 || n == 48:
+			var c string
+			if i+2 < len(p) && p[i+1] == 5 {
+				c = ansiColor(p[i+2])
+				i += 2
+			} else if i+4 < len(p) && p[i+1] == 2 {
+				c = fmt.Sprintf("#%02x%02x%02x", p[i+2]&0xff, p[i+3]&0xff, p[i+4]&0xff)
+				i += 4
+			}
+			if n == 38 {
+				st.fg = c
+			} else {
+				st.bg = c
+			}
+		}
+	}
+}
+
+// ansi16 is the palette used for the 16 basic colors.
+var ansi16 = [16]string{
+	"#1e1e2e", "#f87171", "#34d399", "#fbbf24", "#60a5fa", "#a855f7", "#22d3ee", "#e4e4e7",
+	"#52525b", "#fca5a5", "#6ee7b7", "#fde68a", "#93c5fd", "#d8b4fe", "#67e8f9", "#ffffff",
+}
+
+// ansiColor returns color n of the 256-color palette.
+func ansiColor(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	}
+	g := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", g, g, g)
+}
@@ -0,0 +1,99 @@
+// cells_test.go
+package termshot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// texts returns the text of each cell of a row.
+func texts(row []Cell) []string {
+	var out []string
+	for _, c := range row {
+		out = append(out, c.Text)
+	}
+	return out
+}
+
+func TestCells(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"padded", "ab", []string{"a", "b", " ", " ", " "}},
+		{"cut", "abcdefg", []string{"a", "b", "c", "d", "e"}},
+		{"styles are not text", "\x1b[1;31ma\x1b[0mb", []string{"a", "b", " ", " ", " "}},
+		{"other escapes are skipped", "\x1b]8;;http://x\x1b\\a\x1b]0;t\a\x1b[2Kb", []string{"a", "b", " ", " ", " "}},
+		{"tab", "a\tb", []string{"a", " ", "b", " ", " "}},
+		{"controls dropped", "a\rb\x7f", []string{"a", "b", " ", " ", " "}},
+		{"box drawing", "╭─╮", []string{"╭", "─", "╮", " ", " "}},
+		{"wide emoji", "🐳x", []string{"🐳", "x", " ", " "}},
+		{"combining mark", "e\u0301x", []string{"e\u0301", "x", " ", " ", " "}},
+		{"wide cut at the edge", "abcd🐳z", []string{"a", "b", "c", "d", " "}},
+	}
+	for _, tt := range tests {
+		rows := Cells(tt.in, 5, 1)
+		if got := texts(rows[0]); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Cells(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+		cols := 0
+		for _, c := range rows[0] {
+			cols += c.Width
+		}
+		if cols != 5 {
+			t.Errorf("%s: row takes %d columns, want 5", tt.name, cols)
+		}
+	}
+}
+
+func TestCellsRows(t *testing.T) {
+	rows := Cells("a\nb\nc", 2, 2)
+	if len(rows) != 2 || rows[0][0].Text != "a" || rows[1][0].Text != "b" {
+		t.Errorf("Cells() rows = %v", rows)
+	}
+	rows = Cells("a", 2, 3)
+	if len(rows) != 3 || texts(rows[2])[0] != " " {
+		t.Errorf("Cells() does not pad rows: %v", rows)
+	}
+}
+
+func TestCellStyles(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Style
+	}{
+		{"bold red", "\x1b[1;31mx", Style{fg: ansi16[1], bold: true}},
+		{"bright bg", "\x1b[102mx", Style{bg: ansi16[10]}},
+		{"256 colors", "\x1b[38;5;196;48;5;232mx", Style{fg: "#ff0000", bg: "#080808"}},
+		{"true color", "\x1b[38;2;18;52;86mx", Style{fg: "#123456"}},
+		{"colon form", "\x1b[38:2:18:52:86mx", Style{fg: "#123456"}},
+		{"reset", "\x1b[1;3;4;7;9;31m\x1b[mx", Style{}},
+		{"partial reset", "\x1b[1;2;3;4;7;9;31;41m\x1b[22;23;24;27;29;39;49mx", Style{}},
+		{"carries over lines", "\x1b[4m\nx", Style{underline: true}},
+	}
+	for _, tt := range tests {
+		rows := Cells(tt.in, 1, 2)
+		got := rows[0][0].Style
+		if strings.Contains(tt.in, "\n") {
+			got = rows[1][0].Style
+		}
+		if got != tt.want {
+			t.Errorf("%s: style = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAnsiColor(t *testing.T) {
+	tests := map[int]string{
+		-1: "", 256: "", 0: ansi16[0], 15: ansi16[15],
+		16: "#000000", 21: "#0000ff", 231: "#ffffff", 232: "#080808", 255: "#eeeeee",
+	}
+	for n, want := range tests {
+		if got := ansiColor(n); got != want {
+			t.Errorf("ansiColor(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
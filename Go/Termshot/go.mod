@@ -0,0 +1,5 @@
+module termshot
+
+go 1.21
+
+require github.com/rivo/uniseg v0.4.7
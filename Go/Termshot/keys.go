@@ -0,0 +1,56 @@
+// keys.go
+package termshot
+
+import "strings"
+
+// Key is one key press from a script or a --keys flag: a key the program
+// names, such as "enter" or "ctrl+f", or a typed rune, possibly with alt.
+type Key struct {
+	Name string // "" for a typed Rune
+	Rune rune
+	Alt  bool
+}
+
+// ParseKey turns a word such as "enter", "ctrl+f" or "alt+x" into key
+// presses; known tells which names the program has keys for. Other words
+// are typed rune by rune.
+func ParseKey(word string, known func(name string) bool) []Key {
+	alt := false
+	if rest, ok := strings.CutPrefix(word, "alt+"); ok && rest != "" {
+		alt, word = true, rest
+	}
+	if known(word) {
+		return []Key{{Name: word, Alt: alt}}
+	}
+	var keys []Key
+	for _, r := range word {
+		keys = append(keys, Key{Rune: r, Alt: alt})
+	}
+	return keys
+}
+
+// Presses returns the key presses of a key or type event, nil for other
+// events.
+func (ev Event) Presses(known func(name string) bool) []Key {
+	var keys []Key
+	switch ev.Kind {
+	case EventKeys:
+		for _, k := range ev.Keys {
+			keys = append(keys, ParseKey(k, known)...)
+		}
+	case EventType:
+		for _, r := range ev.Text {
+			keys = append(keys, Key{Rune: r})
+		}
+	}
+	return keys
+}
+
+// KeyEvent is the script event for a key press named the way Bubble Tea
+// prints it; a space, alone or after a modifier, is written as "space".
+func KeyEvent(name string) Event {
+	if strings.HasSuffix(name, " ") {
+		name = strings.TrimSuffix(name, " ") + "space"
+	}
+	return Event{Kind: EventKeys, Keys: []string{name}}
+}
@@ -0,0 +1,58 @@
+// keys_test.go
+package termshot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKey(t *testing.T) {
+	known := func(name string) bool { return name == "enter" || name == "space" || name == "ctrl+f" }
+	tests := []struct {
+		word string
+		want []Key
+	}{
+		{"enter", []Key{{Name: "enter"}}},
+		{"ctrl+f", []Key{{Name: "ctrl+f"}}},
+		{"alt+enter", []Key{{Name: "enter", Alt: true}}},
+		{"alt+x", []Key{{Rune: 'x', Alt: true}}},
+		{"alt+", []Key{{Rune: 'a'}, {Rune: 'l'}, {Rune: 't'}, {Rune: '+'}}},
+		{"hé", []Key{{Rune: 'h'}, {Rune: 'é'}}},
+	}
+	for _, tt := range tests {
+		if got := ParseKey(tt.word, known); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseKey(%q) = %+v, want %+v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestPresses(t *testing.T) {
+	known := func(name string) bool { return name == "down" }
+	tests := []struct {
+		ev   Event
+		want []Key
+	}{
+		{Event{Kind: EventKeys, Keys: []string{"down", "ab"}}, []Key{{Name: "down"}, {Rune: 'a'}, {Rune: 'b'}}},
+		{Event{Kind: EventType, Text: "a down"}, []Key{{Rune: 'a'}, {Rune: ' '}, {Rune: 'd'}, {Rune: 'o'}, {Rune: 'w'}, {Rune: 'n'}}},
+		{Event{Kind: EventResize, Width: 80, Height: 24}, nil},
+	}
+	for _, tt := range tests {
+		if got := tt.ev.Presses(known); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: Presses() = %+v, want %+v", tt.ev, got, tt.want)
+		}
+	}
+}
+
+func TestKeyEvent(t *testing.T) {
+	tests := map[string]string{
+		"enter":  "key enter",
+		" ":      "key space",
+		"alt+ ":  "key alt+space",
+		"ctrl+f": "key ctrl+f",
+	}
+	for name, want := range tests {
+		if got := KeyEvent(name).String(); got != want {
+			t.Errorf("KeyEvent(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// render.go
+package termshot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         SVG & HTML
+// ══════════════════════════════════════════════════════════════════
+
+// Page is what a picture is drawn on: the default background and text
+// colors, and the title of an HTML page.
+type Page struct {
+	Title  string
+	Bg, Fg string
+}
+
+// Geometry of the SVG output, in pixels.
+const (
+	shotFont    = 14
+	shotCellW   = 8.4
+	shotCellH   = 17
+	shotPadding = 16
+)
+
+const shotFontFamily = `'Cascadia Mono', 'JetBrains Mono', Menlo, Consolas, 'DejaVu Sans Mono', monospace`
+
+// run is a stretch of cells in one row sharing a style. Wide cells get a
+// run of their own so they land on their exact column.
+type run struct {
+	Col, Width int
+	Wide       bool
+	Text       string
+	Style      Style
+}
+
+// runs groups a row into runs.
+func runs(row []Cell) []run {
+	var out []run
+	col := 0
+	for _, c := range row {
+		last := len(out) - 1
+		if last >= 0 && c.Width == 1 && !out[last].Wide && out[last].Style == c.Style {
+			out[last].Text += c.Text
+			out[last].Width++
+		} else {
+			out = append(out, run{Col: col, Width: c.Width, Wide: c.Width > 1, Text: c.Text, Style: c.Style})
+		}
+		col += c.Width
+	}
+	return out
+}
+
+// resolve returns the text and background colors of a style; bg is ""
+// when the cell uses the default background.
+func (st Style) resolve(defaultFg, defaultBg string) (fg, bg string) {
+	fg, bg = st.fg, st.bg
+	if fg == "" {
+		fg = defaultFg
+	}
+	if st.reverse {
+		if bg == "" {
+			bg = defaultBg
+		}
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+// Writer picks the SVG or HTML writer by the extension of path.
+func Writer(path string) (func(w io.Writer, rows [][]Cell, p Page), error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return writeSVG, nil
+	case ".html", ".htm":
+		return writeHTML, nil
+	}
+	return nil, fmt.Errorf("unsupported output %q: use .svg or .html", path)
+}
+
+func writeSVG(w io.Writer, rows [][]Cell, p Page) {
+	bg, fg := p.Bg, p.Fg
+	cols := 0
+	for _, c := range rows[0] {
+		cols += c.Width
+	}
+	width := float64(cols)*shotCellW + 2*shotPadding
+	height := float64(len(rows)*shotCellH + 2*shotPadding)
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n", width, height, width, height)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" rx="8" fill="%s"/>`+"\n", bg)
+	fmt.Fprintf(w, `<g font-family="%s" font-size="%d" style="white-space:pre" xml:space="preserve">`+"\n", html.EscapeString(shotFontFamily), shotFont)
+	for y, row := range rows {
+		top := float64(shotPadding + y*shotCellH)
+		for _, r := range runs(row) {
+			x := shotPadding + float64(r.Col)*shotCellW
+			rw := float64(r.Width) * shotCellW
+			fill, back := r.Style.resolve(fg, bg)
+			if back != "" {
+				fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%d" fill="%s"/>`+"\n", x, top, rw, shotCellH, back)
+			}
+			if strings.TrimSpace(r.Text) == "" && !r.Style.underline && !r.Style.strike {
+				continue
+			}
+			var attrs strings.Builder
+			fmt.Fprintf(&attrs, ` fill="%s"`, fill)
+			if r.Style.bold {
+				attrs.WriteString(` font-weight="bold"`)
+			}
+			if r.Style.italic {
+				attrs.WriteString(` font-style="italic"`)
+			}
+			if r.Style.faint {
+				attrs.WriteString(` opacity="0.6"`)
+			}
+			if deco := r.Style.decoration(); deco != "" {
+				fmt.Fprintf(&attrs, ` text-decoration="%s"`, deco)
+			}
+			// textLength pins each run to its cells, so box drawing lines up
+			// whatever the font's advance width.
+			fmt.Fprintf(w, `<text x="%g" y="%g" textLength="%g" lengthAdjust="spacingAndGlyphs"%s>%s</text>`+"\n",
+				x, top+shotCellH*0.75, rw, attrs.String(), html.EscapeString(r.Text))
+		}
+	}
+	fmt.Fprintln(w, "</g>\n</svg>")
+}
+
+func writeHTML(w io.Writer, rows [][]Cell, p Page) {
+	bg, fg := p.Bg, p.Fg
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", html.EscapeString(p.Title))
+	fmt.Fprintf(w, `<pre style="display:inline-block;margin:0;padding:%dpx;border-radius:8px;background:%s;color:%s;font-family:%s;font-size:%dpx;line-height:%dpx">`,
+		shotPadding, bg, fg, html.EscapeString(shotFontFamily), shotFont, shotCellH)
+	for y, row := range rows {
+		if y > 0 {
+			fmt.Fprintln(w)
+		}
+		for _, r := range runs(row) {
+			fill, back := r.Style.resolve(fg, bg)
+			var css []string
+			if fill != fg {
+				css = append(css, "color:"+fill)
+			}
+			if back != "" && back != bg {
+				css = append(css, "background:"+back)
+			}
+			if r.Style.bold {
+				css = append(css, "font-weight:bold")
+			}
+			if r.Style.italic {
+				css = append(css, "font-style:italic")
+			}
+			if r.Style.faint {
+				css = append(css, "opacity:0.6")
+			}
+			if deco := r.Style.decoration(); deco != "" {
+				css = append(css, "text-decoration:"+deco)
+			}
+			if r.Wide {
+				css = append(css, fmt.Sprintf("display:inline-block;width:%dch", r.Width))
+			}
+			text := html.EscapeString(r.Text)
+			if len(css) == 0 {
+				fmt.Fprint(w, text)
+				continue
+			}
+			fmt.Fprintf(w, `<span style="%s">%s</span>`, strings.Join(css, ";"), text)
+		}
+	}
+	fmt.Fprintln(w, "</pre>\n</body>\n</html>")
+}
+
+// decoration returns the CSS text-decoration for the style.
+func (st Style) decoration() string {
+	var d []string
+	if st.underline {
+		d = append(d, "underline")
+	}
+	if st.strike {
+		d = append(d, "line-through")
+	}
+	return strings.Join(d, " ")
+}
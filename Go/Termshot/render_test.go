@@ -0,0 +1,64 @@
+// render_test.go
+package termshot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRuns(t *testing.T) {
+	red := Style{fg: "#ff0000"}
+	row := []Cell{
+		{Text: "a", Width: 1}, {Text: "b", Width: 1},
+		{Text: "c", Width: 1, Style: red},
+		{Text: "🐳", Width: 2, Style: red},
+		{Text: "d", Width: 1, Style: red},
+	}
+	want := []run{
+		{Col: 0, Width: 2, Text: "ab"},
+		{Col: 2, Width: 1, Text: "c", Style: red},
+		{Col: 3, Width: 2, Wide: true, Text: "🐳", Style: red},
+		{Col: 5, Width: 1, Text: "d", Style: red},
+	}
+	if got := runs(row); !reflect.DeepEqual(got, want) {
+		t.Errorf("runs() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	page := Page{Title: "A & B", Bg: "#000000", Fg: "#ffffff"}
+	rows := Cells("\x1b[7m<x>\x1b[0m 🐳", 6, 1)
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"shot.svg", []string{
+			`<svg xmlns="http://www.w3.org/2000/svg"`,
+			`fill="#ffffff"/>`, // reversed background
+			`fill="#000000">&lt;x&gt;</text>`,
+			`>🐳</text>`,
+		}},
+		{"shot.HTML", []string{
+			"<title>A &amp; B</title>",
+			`<span style="color:#000000;background:#ffffff">&lt;x&gt;</span>`,
+			`<span style="display:inline-block;width:2ch">🐳</span>`,
+		}},
+	}
+	for _, tt := range tests {
+		write, err := Writer(tt.path)
+		if err != nil {
+			t.Fatalf("Writer(%q): %v", tt.path, err)
+		}
+		var b strings.Builder
+		write(&b, rows, page)
+		for _, w := range tt.want {
+			if !strings.Contains(b.String(), w) {
+				t.Errorf("%s: output lacks %s\n%s", tt.path, w, b.String())
+			}
+		}
+	}
+	if _, err := Writer("shot.png"); err == nil {
+		t.Error("Writer accepted a .png")
+	}
+}
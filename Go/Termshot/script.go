@@ -0,0 +1,227 @@
+// script.go
+package termshot
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         SESSION SCRIPTS
+// ══════════════════════════════════════════════════════════════════
+//
+// A session script has one event per line; # starts a comment:
+//
+//	resize 140 45
+//	key down down enter       key names as printed by Bubble Tea: ctrl+f, shift+tab, space
+//	type docker               types the rest of the line
+//	mouse press left 12 8     press/release/motion, a button, x y, then shift/alt/ctrl
+//	sleep 250ms               waits before the next event
+//	snap demo.svg             writes the current frame
+//
+// Events are plain values; each program turns them into its own Bubble
+// Tea messages.
+
+// EventKind tells what an Event does.
+type EventKind int
+
+const (
+	EventWait   EventKind = iota // only waits, at the end of a script
+	EventKeys                    // presses Keys in order
+	EventType                    // types Text
+	EventMouse                   // sends Mouse
+	EventResize                  // resizes to Width x Height
+	EventSnap                    // writes the current frame to File
+)
+
+// Event is one line of a script, sent Delay after the previous one.
+type Event struct {
+	Delay         time.Duration
+	Kind          EventKind
+	Keys          []string
+	Text          string
+	Mouse         Mouse
+	Width, Height int
+	File          string
+}
+
+// Mouse is a mouse event. Action is one of MouseActions and Button one of
+// MouseButtons.
+type Mouse struct {
+	Action, Button   string
+	X, Y             int
+	Shift, Alt, Ctrl bool
+}
+
+// MouseActions and MouseButtons are the names a script may use.
+var (
+	MouseActions = []string{"press", "release", "motion"}
+	MouseButtons = []string{
+		"none", "left", "middle", "right",
+		"wheelup", "wheeldown", "wheelleft", "wheelright",
+		"backward", "forward",
+	}
+)
+
+// LoadScript reads and parses the script at path.
+func LoadScript(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	events, err := ParseScript(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return events, nil
+}
+
+// ParseScript turns script lines into events. Sleeps add to the delay of
+// the next event; a sleep at the end becomes an EventWait.
+func ParseScript(r io.Reader) ([]Event, error) {
+	var events []Event
+	var delay time.Duration
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimLeft(strings.TrimRight(sc.Text(), "\r"), " \t")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		verb, rest, _ := strings.Cut(line, " ")
+		args := strings.Fields(rest)
+
+		var ev Event
+		switch verb {
+		case "sleep":
+			d, err := time.ParseDuration(rest)
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("line %d: bad duration %q", n, rest)
+			}
+			delay += d
+			continue
+		case "key":
+			if len(args) == 0 {
+				return nil, fmt.Errorf("line %d: key needs a key name", n)
+			}
+			ev = Event{Kind: EventKeys, Keys: args}
+		case "type":
+			if rest == "" {
+				continue
+			}
+			ev = Event{Kind: EventType, Text: rest}
+		case "mouse":
+			mouse, err := ParseMouse(args)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			ev = Event{Kind: EventMouse, Mouse: mouse}
+		case "resize":
+			w, h, err := parseXY(args)
+			if err != nil || w < 1 || h < 1 {
+				return nil, fmt.Errorf("line %d: resize needs a width and height", n)
+			}
+			ev = Event{Kind: EventResize, Width: w, Height: h}
+		case "snap":
+			if rest == "" {
+				return nil, fmt.Errorf("line %d: snap needs a file name", n)
+			}
+			ev = Event{Kind: EventSnap, File: strings.TrimSpace(rest)}
+		default:
+			return nil, fmt.Errorf("line %d: unknown event %q", n, verb)
+		}
+		ev.Delay, delay = delay, 0
+		events = append(events, ev)
+	}
+	if delay > 0 {
+		events = append(events, Event{Delay: delay})
+	}
+	return events, sc.Err()
+}
+
+// parseXY reads two integers.
+func parseXY(args []string) (int, int, error) {
+	if len(args) != 2 {
+		return 0, 0, errors.New("expected two numbers")
+	}
+	x, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := strconv.Atoi(args[1])
+	return x, y, err
+}
+
+// ParseMouse reads "press left 12 8 [shift] [alt] [ctrl]".
+func ParseMouse(args []string) (Mouse, error) {
+	var m Mouse
+	if len(args) < 4 {
+		return m, errors.New("mouse needs an action, a button and x y")
+	}
+	if !contains(MouseActions, args[0]) {
+		return m, fmt.Errorf("unknown mouse action %q", args[0])
+	}
+	if !contains(MouseButtons, args[1]) {
+		return m, fmt.Errorf("unknown mouse button %q", args[1])
+	}
+	x, y, err := parseXY(args[2:4])
+	if err != nil {
+		return m, fmt.Errorf("bad mouse position: %w", err)
+	}
+	m = Mouse{Action: args[0], Button: args[1], X: x, Y: y}
+	for _, mod := range args[4:] {
+		switch mod {
+		case "shift":
+			m.Shift = true
+		case "alt":
+			m.Alt = true
+		case "ctrl":
+			m.Ctrl = true
+		default:
+			return m, fmt.Errorf("unknown modifier %q", mod)
+		}
+	}
+	return m, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the event as a script line, without its delay.
+func (ev Event) String() string {
+	switch ev.Kind {
+	case EventKeys:
+		return "key " + strings.Join(ev.Keys, " ")
+	case EventType:
+		return "type " + ev.Text
+	case EventMouse:
+		m := ev.Mouse
+		line := fmt.Sprintf("mouse %s %s %d %d", m.Action, m.Button, m.X, m.Y)
+		for _, mod := range []struct {
+			on   bool
+			name string
+		}{{m.Shift, "shift"}, {m.Alt, "alt"}, {m.Ctrl, "ctrl"}} {
+			if mod.on {
+				line += " " + mod.name
+			}
+		}
+		return line
+	case EventResize:
+		return fmt.Sprintf("resize %d %d", ev.Width, ev.Height)
+	case EventSnap:
+		return "snap " + ev.File
+	}
+	return ""
+}
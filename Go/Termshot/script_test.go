@@ -0,0 +1,115 @@
+// script_test.go
+package termshot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseScript(t *testing.T) {
+	script := strings.Join([]string{
+		"# demo",
+		"resize 100 30",
+		"",
+		"sleep 200ms",
+		"key down  ctrl+f",
+		"  type docker ps\r",
+		"type ",
+		"sleep 1s",
+		"sleep 500ms",
+		"mouse press left 3 4 shift",
+		"snap shots/one.svg ",
+		"sleep 2s",
+	}, "\n")
+	want := []Event{
+		{Kind: EventResize, Width: 100, Height: 30},
+		{Delay: 200 * time.Millisecond, Kind: EventKeys, Keys: []string{"down", "ctrl+f"}},
+		{Kind: EventType, Text: "docker ps"},
+		{Delay: 1500 * time.Millisecond, Kind: EventMouse,
+			Mouse: Mouse{Action: "press", Button: "left", X: 3, Y: 4, Shift: true}},
+		{Kind: EventSnap, File: "shots/one.svg"},
+		{Delay: 2 * time.Second},
+	}
+	got, err := ParseScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseScript() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	tests := []struct {
+		script string
+		err    string
+	}{
+		{"sleep soon", `line 1: bad duration "soon"`},
+		{"sleep -1s", `line 1: bad duration "-1s"`},
+		{"# x\nkey", "line 2: key needs a key name"},
+		{"resize 80", "line 1: resize needs a width and height"},
+		{"resize 0 10", "line 1: resize needs a width and height"},
+		{"snap", "line 1: snap needs a file name"},
+		{"mouse press left", "line 1: mouse needs an action, a button and x y"},
+		{"jump 3", `line 1: unknown event "jump"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseScript(strings.NewReader(tt.script))
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("ParseScript(%q) error = %v, want %s", tt.script, err, tt.err)
+		}
+	}
+}
+
+func TestParseMouse(t *testing.T) {
+	tests := []struct {
+		args string
+		want Mouse
+		err  string
+	}{
+		{"press left 12 8", Mouse{Action: "press", Button: "left", X: 12, Y: 8}, ""},
+		{"release none 0 0", Mouse{Action: "release", Button: "none"}, ""},
+		{"motion wheelup 1 2 ctrl alt shift",
+			Mouse{Action: "motion", Button: "wheelup", X: 1, Y: 2, Shift: true, Alt: true, Ctrl: true}, ""},
+		{"click left 1 2", Mouse{}, `unknown mouse action "click"`},
+		{"press thumb 1 2", Mouse{}, `unknown mouse button "thumb"`},
+		{"press left x 2", Mouse{}, `bad mouse position: strconv.Atoi: parsing "x": invalid syntax`},
+		{"press left 1 2 meta", Mouse{Action: "press", Button: "left", X: 1, Y: 2}, `unknown modifier "meta"`},
+	}
+	for _, tt := range tests {
+		got, err := ParseMouse(strings.Fields(tt.args))
+		if msg := errString(err); msg != tt.err || (err == nil && got != tt.want) {
+			t.Errorf("ParseMouse(%q) = %+v, %q, want %+v, %q", tt.args, got, msg, tt.want, tt.err)
+		}
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func TestEventString(t *testing.T) {
+	for _, line := range []string{
+		"key down ctrl+f",
+		"type docker ps",
+		"mouse press wheeldown 12 8 shift ctrl",
+		"resize 140 45",
+		"snap demo.svg",
+	} {
+		events, err := ParseScript(strings.NewReader(line))
+		if err != nil || len(events) != 1 {
+			t.Fatalf("ParseScript(%q) = %v, %v", line, events, err)
+		}
+		if got := events[0].String(); got != line {
+			t.Errorf("String() = %q, want %q", got, line)
+		}
+	}
+	if got := (Event{Delay: time.Second}).String(); got != "" {
+		t.Errorf("wait String() = %q, want empty", got)
+	}
+}
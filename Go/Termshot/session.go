@@ -0,0 +1,195 @@
+// session.go
+package termshot
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ══════════════════════════════════════════════════════════════════
+//                         SESSIONS
+// ══════════════════════════════════════════════════════════════════
+//
+// --replay feeds a session script into the running program and quits at
+// its end; --record writes the live session in the same format. A Session
+// does everything but talk to Bubble Tea: the program wraps its model to
+// hand input and frames to it, and sends it the script's events.
+
+// Options are the replay and recording flags of a program.
+type Options struct {
+	Replay string
+	Record string
+	Frames string
+	Final  string
+}
+
+func (o *Options) Register(fs *flag.FlagSet) {
+	fs.StringVar(&o.Replay, "replay", "", "play the events in this script, then quit")
+	fs.StringVar(&o.Record, "record", "", "record the session into this script")
+	fs.StringVar(&o.Frames, "frames", "", "write every frame into this directory")
+	fs.StringVar(&o.Final, "final", "", "write the last frame to this file (.svg, .html or ANSI text)")
+}
+
+// Active reports whether any of the flags is set.
+func (o Options) Active() bool {
+	return o.Replay != "" || o.Record != "" || o.Frames != "" || o.Final != ""
+}
+
+// Session holds the script, the recording and the frame output of one
+// run. Page gives the colors of pictures at the time they are written.
+type Session struct {
+	Opts   Options
+	Events []Event // the replay script, nil without --replay
+	Page   func() Page
+
+	record        *os.File
+	rec           *bufio.Writer
+	lastEvent     time.Time
+	frame         int
+	lastFrame     string
+	width, height int
+	pinned        bool // a script set the size
+	err           error
+}
+
+// NewSession loads the script and creates the recording and the frames
+// directory the options ask for.
+func NewSession(o Options, page func() Page) (*Session, error) {
+	s := &Session{Opts: o, Page: page}
+	if o.Replay != "" {
+		events, err := LoadScript(o.Replay)
+		if err != nil {
+			return nil, err
+		}
+		s.Events = events
+	}
+	if o.Frames != "" {
+		if err := os.MkdirAll(o.Frames, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if o.Record != "" {
+		f, err := os.Create(o.Record)
+		if err != nil {
+			return nil, err
+		}
+		s.record, s.rec = f, bufio.NewWriter(f)
+		fmt.Fprintf(s.rec, "# recorded %s\n", time.Now().Format(time.RFC3339))
+	}
+	return s, nil
+}
+
+// Play sends the script's events, each after its delay, then calls done.
+func (s *Session) Play(send func(Event), done func()) {
+	for _, ev := range s.Events {
+		time.Sleep(ev.Delay)
+		if ev.Kind != EventWait {
+			send(ev)
+		}
+	}
+	done()
+}
+
+// Resize takes a new size, from the script or from the terminal. Once a
+// script has set the size, terminal resizes are ignored and Resize
+// returns false.
+func (s *Session) Resize(width, height int, fromScript bool) bool {
+	if fromScript {
+		s.pinned = true
+	} else if s.pinned {
+		return false
+	}
+	s.width, s.height = width, height
+	return true
+}
+
+// Record appends an input event to the recording, preceded by the time
+// since the last one.
+func (s *Session) Record(ev Event) {
+	line := ev.String()
+	if s.rec == nil || line == "" {
+		return
+	}
+	now := time.Now()
+	if !s.lastEvent.IsZero() {
+		if d := now.Sub(s.lastEvent).Round(time.Millisecond); d > 0 {
+			fmt.Fprintf(s.rec, "sleep %s\n", d)
+		}
+	}
+	s.lastEvent = now
+	fmt.Fprintln(s.rec, line)
+}
+
+// Snap writes view to path, for a snap event.
+func (s *Session) Snap(path, view string) {
+	s.keep(WriteFrame(path, view, s.width, s.height, s.Page()))
+}
+
+// Frame writes view into the frames directory when it differs from the
+// last one.
+func (s *Session) Frame(view string) {
+	if s.Opts.Frames == "" || view == s.lastFrame {
+		return
+	}
+	s.frame++
+	s.lastFrame = view
+	path := filepath.Join(s.Opts.Frames, fmt.Sprintf("frame-%05d.ans", s.frame))
+	s.keep(os.WriteFile(path, []byte(view), 0o644))
+}
+
+// Finish writes the last frame of a run that ended normally.
+func (s *Session) Finish(view string) {
+	if s.Opts.Final != "" {
+		s.keep(WriteFrame(s.Opts.Final, view, s.width, s.height, s.Page()))
+	}
+}
+
+// Close ends the recording and returns the first error of the session.
+func (s *Session) Close() error {
+	if s.record != nil {
+		s.keep(s.rec.Flush())
+		s.keep(s.record.Close())
+	}
+	return s.err
+}
+
+// keep remembers the first error for the end of the session.
+func (s *Session) keep(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// WriteFrame writes a frame to path: as SVG or HTML by extension, or as
+// ANSI text. A zero width or height is measured from view.
+func WriteFrame(path, view string, width, height int, p Page) error {
+	write, err := Writer(path)
+	if err != nil {
+		return os.WriteFile(path, []byte(view), 0o644)
+	}
+	if width == 0 || height == 0 {
+		width, height = size(view)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	write(f, Cells(view, width, height), p)
+	return f.Close()
+}
+
+// size returns the columns and lines ANSI output takes.
+func size(s string) (width, height int) {
+	var st Style
+	lines := strings.Split(s, "\n")
+	for _, line := range lines {
+		_, w := parseLine(line, int(^uint(0)>>1), &st)
+		width = max(width, w)
+	}
+	return width, len(lines)
+}
@@ -0,0 +1,121 @@
+// session_test.go
+package termshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionPlay(t *testing.T) {
+	s := &Session{Events: []Event{
+		{Delay: 20 * time.Millisecond, Kind: EventKeys, Keys: []string{"down"}},
+		{Kind: EventType, Text: "x"},
+		{Delay: 30 * time.Millisecond},
+	}}
+	var got []string
+	done := false
+	start := time.Now()
+	s.Play(func(ev Event) { got = append(got, ev.String()) }, func() { done = true })
+	if want := []string{"key down", "type x"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+	if !done {
+		t.Error("done was not called")
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("played in %v, want at least 50ms", d)
+	}
+}
+
+func TestSessionRecord(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rec.txt")
+	s, err := NewSession(Options{Record: path}, func() Page { return Page{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Record(KeyEvent("down"))
+	s.Record(Event{}) // not input: nothing is written
+	time.Sleep(10 * time.Millisecond)
+	s.Record(Event{Kind: EventResize, Width: 80, Height: 24})
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "# recorded ") ||
+		lines[1] != "key down" || !strings.HasPrefix(lines[2], "sleep ") || lines[3] != "resize 80 24" {
+		t.Fatalf("recording =\n%s", data)
+	}
+	events, err := ParseScript(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Delay < 10*time.Millisecond {
+		t.Errorf("recording plays back as %+v", events)
+	}
+}
+
+func TestSessionResize(t *testing.T) {
+	s := &Session{}
+	if !s.Resize(100, 30, false) || s.width != 100 {
+		t.Error("terminal resize before a script resize was ignored")
+	}
+	if !s.Resize(80, 24, true) || s.width != 80 {
+		t.Error("script resize was ignored")
+	}
+	if s.Resize(120, 40, false) || s.width != 80 {
+		t.Error("terminal resize after a script resize was taken")
+	}
+}
+
+func TestSessionFrames(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "frames")
+	s, err := NewSession(Options{Frames: dir}, func() Page { return Page{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"a", "a", "b"} {
+		s.Frame(v)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Name() != "frame-00002.ans" {
+		t.Errorf("frames = %v, want two", entries)
+	}
+}
+
+func TestWriteFrame(t *testing.T) {
+	dir := t.TempDir()
+	view := "\x1b[31mred\x1b[0m\nlonger line"
+	for _, name := range []string{"f.svg", "f.html", "f.ans"} {
+		path := filepath.Join(dir, name)
+		if err := WriteFrame(path, view, 0, 0, Page{Title: "t"}); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "f.ans" && string(data) != view {
+			t.Errorf("%s = %q, want the view as is", name, data)
+		}
+		if name != "f.ans" && !strings.Contains(string(data), "longer") {
+			t.Errorf("%s is missing the second line", name)
+		}
+	}
+	if w, h := size(view); w != 11 || h != 2 {
+		t.Errorf("size() = %d, %d, want 11, 2", w, h)
+	}
+}